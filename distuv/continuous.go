@@ -0,0 +1,840 @@
+package distuv
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/jaumefe/stats/mathext"
+)
+
+/*
+Continuous represents a univariate continuous probability distribution:
+  - PDF: probability density function
+  - LogPDF: natural logarithm of the probability density function
+  - CDF: cumulative distribution function, P(X <= x)
+  - Survival: survival function, P(X > x)
+  - Quantile: inverse of the cumulative distribution function
+  - Mean, Variance and Entropy (in nats) of the distribution
+  - Rand and Sample: random values drawn from the distribution, whose source is set through SetSource
+*/
+type Continuous interface {
+	PDF(x float64) float64
+	LogPDF(x float64) float64
+	CDF(x float64) float64
+	Survival(x float64) float64
+	Quantile(p float64) float64
+	Mean() float64
+	Variance() float64
+	Entropy() float64
+	Rand() float64
+	Sample(n int) []float64
+	SetSource(src rand.Source)
+}
+
+var (
+	_ Continuous = (*Normal)(nil)
+	_ Continuous = (*Exponential)(nil)
+	_ Continuous = (*Uniform)(nil)
+	_ Continuous = (*Gamma)(nil)
+	_ Continuous = (*Beta)(nil)
+	_ Continuous = (*LogNormal)(nil)
+	_ Continuous = (*StudentsT)(nil)
+	_ Continuous = (*ChiSquared)(nil)
+	_ Continuous = (*F)(nil)
+)
+
+var logSqrt2Pi = 0.5 * math.Log(2*math.Pi)
+
+// Returns whether p is a valid probability for a quantile query
+func validProbability(p float64) bool {
+	return p >= 0 && p <= 1
+}
+
+/*
+Normal represents a normal (gaussian) distribution with mean mu and
+standard deviation sigma
+*/
+type Normal struct {
+	mu    float64
+	sigma float64
+
+	source
+}
+
+/*
+NewNormal returns a new Normal distribution.
+It returns an error if sigma is not a finite value greater than 0
+*/
+func NewNormal(mu, sigma float64) (*Normal, error) {
+	if !(sigma > 0) || math.IsInf(sigma, 1) {
+		return nil, ErrInvalidScale
+	}
+
+	return &Normal{mu: mu, sigma: sigma}, nil
+}
+
+// Returns the probability density at x
+func (n *Normal) PDF(x float64) float64 {
+	return math.Exp(n.LogPDF(x))
+}
+
+// Returns the logarithm of the probability density at x
+func (n *Normal) LogPDF(x float64) float64 {
+	z := (x - n.mu) / n.sigma
+	return -0.5*z*z - math.Log(n.sigma) - logSqrt2Pi
+}
+
+// Returns the probability of a value being less or equal than x
+func (n *Normal) CDF(x float64) float64 {
+	return 0.5 * math.Erfc(-(x-n.mu)/(n.sigma*math.Sqrt2))
+}
+
+// Returns the probability of a value being greater than x
+func (n *Normal) Survival(x float64) float64 {
+	return 0.5 * math.Erfc((x-n.mu)/(n.sigma*math.Sqrt2))
+}
+
+// Returns the value x such that CDF(x) = p. It returns NaN if p is out of range (0 - 1)
+func (n *Normal) Quantile(p float64) float64 {
+	if !validProbability(p) {
+		return math.NaN()
+	}
+
+	return n.mu - n.sigma*math.Sqrt2*math.Erfcinv(2*p)
+}
+
+// Returns the mean of the distribution
+func (n *Normal) Mean() float64 {
+	return n.mu
+}
+
+// Returns the variance of the distribution
+func (n *Normal) Variance() float64 {
+	return n.sigma * n.sigma
+}
+
+// Returns the entropy of the distribution
+func (n *Normal) Entropy() float64 {
+	return 0.5 + logSqrt2Pi + math.Log(n.sigma)
+}
+
+// Exponential represents an exponential distribution with a given rate
+type Exponential struct {
+	rate float64
+
+	source
+}
+
+/*
+NewExponential returns a new Exponential distribution.
+It returns an error if rate is not greater than 0
+*/
+func NewExponential(rate float64) (*Exponential, error) {
+	if !(rate > 0) {
+		return nil, ErrInvalidRate
+	}
+
+	return &Exponential{rate: rate}, nil
+}
+
+// Returns the probability density at x
+func (e *Exponential) PDF(x float64) float64 {
+	return math.Exp(e.LogPDF(x))
+}
+
+// Returns the logarithm of the probability density at x
+func (e *Exponential) LogPDF(x float64) float64 {
+	if x < 0 {
+		return math.Inf(-1)
+	}
+
+	return math.Log(e.rate) - e.rate*x
+}
+
+// Returns the probability of a value being less or equal than x
+func (e *Exponential) CDF(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+
+	return -math.Expm1(-e.rate * x)
+}
+
+// Returns the probability of a value being greater than x
+func (e *Exponential) Survival(x float64) float64 {
+	if x < 0 {
+		return 1
+	}
+
+	return math.Exp(-e.rate * x)
+}
+
+// Returns the value x such that CDF(x) = p. It returns NaN if p is out of range (0 - 1)
+func (e *Exponential) Quantile(p float64) float64 {
+	if !validProbability(p) {
+		return math.NaN()
+	}
+
+	return -math.Log1p(-p) / e.rate
+}
+
+// Returns the mean of the distribution
+func (e *Exponential) Mean() float64 {
+	return 1 / e.rate
+}
+
+// Returns the variance of the distribution
+func (e *Exponential) Variance() float64 {
+	return 1 / (e.rate * e.rate)
+}
+
+// Returns the entropy of the distribution
+func (e *Exponential) Entropy() float64 {
+	return 1 - math.Log(e.rate)
+}
+
+// Uniform represents a continuous uniform distribution on the interval [min, max]
+type Uniform struct {
+	min float64
+	max float64
+
+	source
+}
+
+/*
+NewUniform returns a new Uniform distribution.
+It returns an error if min is not less than max
+*/
+func NewUniform(min, max float64) (*Uniform, error) {
+	if !(min < max) || math.IsInf(min, 0) || math.IsInf(max, 0) {
+		return nil, ErrInvalidBounds
+	}
+
+	return &Uniform{min: min, max: max}, nil
+}
+
+// Returns the probability density at x
+func (u *Uniform) PDF(x float64) float64 {
+	if x < u.min || x > u.max {
+		return 0
+	}
+
+	return 1 / (u.max - u.min)
+}
+
+// Returns the logarithm of the probability density at x
+func (u *Uniform) LogPDF(x float64) float64 {
+	if x < u.min || x > u.max {
+		return math.Inf(-1)
+	}
+
+	return -math.Log(u.max - u.min)
+}
+
+// Returns the probability of a value being less or equal than x
+func (u *Uniform) CDF(x float64) float64 {
+	if x <= u.min {
+		return 0
+	}
+	if x >= u.max {
+		return 1
+	}
+
+	return (x - u.min) / (u.max - u.min)
+}
+
+// Returns the probability of a value being greater than x
+func (u *Uniform) Survival(x float64) float64 {
+	if x <= u.min {
+		return 1
+	}
+	if x >= u.max {
+		return 0
+	}
+
+	return (u.max - x) / (u.max - u.min)
+}
+
+// Returns the value x such that CDF(x) = p. It returns NaN if p is out of range (0 - 1)
+func (u *Uniform) Quantile(p float64) float64 {
+	if !validProbability(p) {
+		return math.NaN()
+	}
+
+	return u.min + p*(u.max-u.min)
+}
+
+// Returns the mean of the distribution
+func (u *Uniform) Mean() float64 {
+	return (u.min + u.max) / 2
+}
+
+// Returns the variance of the distribution
+func (u *Uniform) Variance() float64 {
+	w := u.max - u.min
+	return w * w / 12
+}
+
+// Returns the entropy of the distribution
+func (u *Uniform) Entropy() float64 {
+	return math.Log(u.max - u.min)
+}
+
+// Gamma represents a gamma distribution given its shape and rate (inverse of scale)
+type Gamma struct {
+	shape float64
+	rate  float64
+
+	source
+}
+
+/*
+NewGamma returns a new Gamma distribution.
+It returns an error if shape or rate are not greater than 0
+*/
+func NewGamma(shape, rate float64) (*Gamma, error) {
+	if !(shape > 0) || math.IsInf(shape, 1) {
+		return nil, ErrInvalidShape
+	}
+
+	if !(rate > 0) || math.IsInf(rate, 1) {
+		return nil, ErrInvalidRate
+	}
+
+	return &Gamma{shape: shape, rate: rate}, nil
+}
+
+// Returns the probability density at x
+func (g *Gamma) PDF(x float64) float64 {
+	return math.Exp(g.LogPDF(x))
+}
+
+// Returns the logarithm of the probability density at x
+func (g *Gamma) LogPDF(x float64) float64 {
+	if x < 0 {
+		return math.Inf(-1)
+	}
+
+	if x == 0 {
+		switch {
+		case g.shape < 1:
+			return math.Inf(1)
+		case g.shape == 1:
+			return math.Log(g.rate)
+		default:
+			return math.Inf(-1)
+		}
+	}
+
+	return g.shape*math.Log(g.rate) - mathext.Lgamma(g.shape) + (g.shape-1)*math.Log(x) - g.rate*x
+}
+
+// Returns the probability of a value being less or equal than x
+func (g *Gamma) CDF(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+
+	return mathext.GammaIncReg(g.shape, g.rate*x)
+}
+
+// Returns the probability of a value being greater than x
+func (g *Gamma) Survival(x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+
+	return mathext.GammaIncRegComp(g.shape, g.rate*x)
+}
+
+// Returns the value x such that CDF(x) = p. It returns NaN if p is out of range (0 - 1)
+func (g *Gamma) Quantile(p float64) float64 {
+	if !validProbability(p) {
+		return math.NaN()
+	}
+
+	return mathext.GammaIncRegInv(g.shape, p) / g.rate
+}
+
+// Returns the mean of the distribution
+func (g *Gamma) Mean() float64 {
+	return g.shape / g.rate
+}
+
+// Returns the variance of the distribution
+func (g *Gamma) Variance() float64 {
+	return g.shape / (g.rate * g.rate)
+}
+
+// Returns the entropy of the distribution
+func (g *Gamma) Entropy() float64 {
+	return g.shape - math.Log(g.rate) + mathext.Lgamma(g.shape) + (1-g.shape)*mathext.Digamma(g.shape)
+}
+
+// Beta represents a beta distribution on the interval [0, 1] given its two shape parameters
+type Beta struct {
+	alpha float64
+	beta  float64
+
+	source
+}
+
+/*
+NewBeta returns a new Beta distribution.
+It returns an error if any of the shape parameters is not greater than 0
+*/
+func NewBeta(alpha, beta float64) (*Beta, error) {
+	if !(alpha > 0) || !(beta > 0) || math.IsInf(alpha, 1) || math.IsInf(beta, 1) {
+		return nil, ErrInvalidShape
+	}
+
+	return &Beta{alpha: alpha, beta: beta}, nil
+}
+
+// Returns the probability density at x
+func (b *Beta) PDF(x float64) float64 {
+	return math.Exp(b.LogPDF(x))
+}
+
+// Returns the logarithm of the probability density at x
+func (b *Beta) LogPDF(x float64) float64 {
+	if x < 0 || x > 1 {
+		return math.Inf(-1)
+	}
+
+	// Edges are handled apart to avoid 0 * -Inf when a shape parameter is 1
+	if x == 0 {
+		return betaEdgeLogPDF(b.alpha, b.beta)
+	}
+	if x == 1 {
+		return betaEdgeLogPDF(b.beta, b.alpha)
+	}
+
+	return (b.alpha-1)*math.Log(x) + (b.beta-1)*math.Log1p(-x) - mathext.Lbeta(b.alpha, b.beta)
+}
+
+// Returns the log-density of a beta distribution at the edge governed by the shape parameter near
+func betaEdgeLogPDF(near, far float64) float64 {
+	switch {
+	case near < 1:
+		return math.Inf(1)
+	case near == 1:
+		return -mathext.Lbeta(near, far)
+	default:
+		return math.Inf(-1)
+	}
+}
+
+// Returns the probability of a value being less or equal than x
+func (b *Beta) CDF(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+
+	return mathext.BetaIncReg(b.alpha, b.beta, x)
+}
+
+// Returns the probability of a value being greater than x
+func (b *Beta) Survival(x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	if x >= 1 {
+		return 0
+	}
+
+	return mathext.BetaIncReg(b.beta, b.alpha, 1-x)
+}
+
+// Returns the value x such that CDF(x) = p. It returns NaN if p is out of range (0 - 1)
+func (b *Beta) Quantile(p float64) float64 {
+	if !validProbability(p) {
+		return math.NaN()
+	}
+
+	return mathext.BetaIncRegInv(b.alpha, b.beta, p)
+}
+
+// Returns the mean of the distribution
+func (b *Beta) Mean() float64 {
+	return b.alpha / (b.alpha + b.beta)
+}
+
+// Returns the variance of the distribution
+func (b *Beta) Variance() float64 {
+	s := b.alpha + b.beta
+	return b.alpha * b.beta / (s * s * (s + 1))
+}
+
+// Returns the entropy of the distribution
+func (b *Beta) Entropy() float64 {
+	return mathext.Lbeta(b.alpha, b.beta) - (b.alpha-1)*mathext.Digamma(b.alpha) - (b.beta-1)*mathext.Digamma(b.beta) +
+		(b.alpha+b.beta-2)*mathext.Digamma(b.alpha+b.beta)
+}
+
+/*
+LogNormal represents a log-normal distribution, whose logarithm is normally
+distributed with mean mu and standard deviation sigma
+*/
+type LogNormal struct {
+	mu    float64
+	sigma float64
+
+	source
+}
+
+/*
+NewLogNormal returns a new LogNormal distribution.
+It returns an error if sigma is not a finite value greater than 0
+*/
+func NewLogNormal(mu, sigma float64) (*LogNormal, error) {
+	if !(sigma > 0) || math.IsInf(sigma, 1) {
+		return nil, ErrInvalidScale
+	}
+
+	return &LogNormal{mu: mu, sigma: sigma}, nil
+}
+
+// Returns the probability density at x
+func (l *LogNormal) PDF(x float64) float64 {
+	return math.Exp(l.LogPDF(x))
+}
+
+// Returns the logarithm of the probability density at x
+func (l *LogNormal) LogPDF(x float64) float64 {
+	if x <= 0 {
+		return math.Inf(-1)
+	}
+
+	lx := math.Log(x)
+	z := (lx - l.mu) / l.sigma
+	return -0.5*z*z - lx - math.Log(l.sigma) - logSqrt2Pi
+}
+
+// Returns the probability of a value being less or equal than x
+func (l *LogNormal) CDF(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+
+	return 0.5 * math.Erfc(-(math.Log(x)-l.mu)/(l.sigma*math.Sqrt2))
+}
+
+// Returns the probability of a value being greater than x
+func (l *LogNormal) Survival(x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+
+	return 0.5 * math.Erfc((math.Log(x)-l.mu)/(l.sigma*math.Sqrt2))
+}
+
+// Returns the value x such that CDF(x) = p. It returns NaN if p is out of range (0 - 1)
+func (l *LogNormal) Quantile(p float64) float64 {
+	if !validProbability(p) {
+		return math.NaN()
+	}
+
+	return math.Exp(l.mu - l.sigma*math.Sqrt2*math.Erfcinv(2*p))
+}
+
+// Returns the mean of the distribution
+func (l *LogNormal) Mean() float64 {
+	return math.Exp(l.mu + l.sigma*l.sigma/2)
+}
+
+// Returns the variance of the distribution
+func (l *LogNormal) Variance() float64 {
+	s2 := l.sigma * l.sigma
+	return math.Expm1(s2) * math.Exp(2*l.mu+s2)
+}
+
+// Returns the entropy of the distribution
+func (l *LogNormal) Entropy() float64 {
+	return l.mu + 0.5 + logSqrt2Pi + math.Log(l.sigma)
+}
+
+// StudentsT represents a standard Student's t distribution with nu degrees of freedom
+type StudentsT struct {
+	nu float64
+
+	source
+}
+
+/*
+NewStudentsT returns a new StudentsT distribution.
+Degrees of freedom are allowed to be any positive real number.
+It returns an error if nu is not greater than 0
+*/
+func NewStudentsT(nu float64) (*StudentsT, error) {
+	if !(nu > 0) {
+		return nil, ErrInvalidDegrees
+	}
+
+	return &StudentsT{nu: nu}, nil
+}
+
+// Returns the probability density at x
+func (s *StudentsT) PDF(x float64) float64 {
+	return math.Exp(s.LogPDF(x))
+}
+
+// Returns the logarithm of the probability density at x
+func (s *StudentsT) LogPDF(x float64) float64 {
+	return mathext.Lgamma((s.nu+1)/2) - mathext.Lgamma(s.nu/2) - 0.5*math.Log(s.nu*math.Pi) -
+		(s.nu+1)/2*math.Log1p(x*x/s.nu)
+}
+
+// Returns the probability of a value being less or equal than x
+func (s *StudentsT) CDF(x float64) float64 {
+	if x > 0 {
+		return 1 - s.tail(x)
+	}
+	return s.tail(x)
+}
+
+// Returns the probability of a value being greater than x
+func (s *StudentsT) Survival(x float64) float64 {
+	if x > 0 {
+		return s.tail(x)
+	}
+	return 1 - s.tail(x)
+}
+
+// Returns the probability of a value being further from 0 than |x| on one side
+func (s *StudentsT) tail(x float64) float64 {
+	if math.IsInf(x, 0) {
+		return 0
+	}
+
+	return 0.5 * mathext.BetaIncReg(s.nu/2, 0.5, s.nu/(s.nu+x*x))
+}
+
+// Returns the value x such that CDF(x) = p. It returns NaN if p is out of range (0 - 1)
+func (s *StudentsT) Quantile(p float64) float64 {
+	if !validProbability(p) {
+		return math.NaN()
+	}
+
+	if p == 0.5 {
+		return 0
+	}
+
+	x := mathext.BetaIncRegInv(s.nu/2, 0.5, 2*math.Min(p, 1-p))
+	t := math.Sqrt(s.nu * (1/x - 1))
+	if p < 0.5 {
+		return -t
+	}
+	return t
+}
+
+// Returns the mean of the distribution. It is only defined when nu > 1, otherwise NaN is returned
+func (s *StudentsT) Mean() float64 {
+	if s.nu <= 1 {
+		return math.NaN()
+	}
+	return 0
+}
+
+/*
+Returns the variance of the distribution.
+It is infinite when 1 < nu <= 2 and undefined (NaN) when nu <= 1
+*/
+func (s *StudentsT) Variance() float64 {
+	switch {
+	case s.nu > 2:
+		return s.nu / (s.nu - 2)
+	case s.nu > 1:
+		return math.Inf(1)
+	default:
+		return math.NaN()
+	}
+}
+
+// Returns the entropy of the distribution
+func (s *StudentsT) Entropy() float64 {
+	h := (s.nu + 1) / 2
+	return h*(mathext.Digamma(h)-mathext.Digamma(s.nu/2)) + 0.5*math.Log(s.nu) + mathext.Lbeta(s.nu/2, 0.5)
+}
+
+// ChiSquared represents a chi-squared distribution with k degrees of freedom
+type ChiSquared struct {
+	k float64
+
+	source
+}
+
+/*
+NewChiSquared returns a new ChiSquared distribution.
+Degrees of freedom are allowed to be any positive real number.
+It returns an error if k is not greater than 0
+*/
+func NewChiSquared(k float64) (*ChiSquared, error) {
+	if !(k > 0) || math.IsInf(k, 1) {
+		return nil, ErrInvalidDegrees
+	}
+
+	return &ChiSquared{k: k}, nil
+}
+
+// Returns the probability density at x
+func (c *ChiSquared) PDF(x float64) float64 {
+	return math.Exp(c.LogPDF(x))
+}
+
+// Returns the logarithm of the probability density at x
+func (c *ChiSquared) LogPDF(x float64) float64 {
+	g := Gamma{shape: c.k / 2, rate: 0.5}
+	return g.LogPDF(x)
+}
+
+// Returns the probability of a value being less or equal than x
+func (c *ChiSquared) CDF(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+
+	return mathext.GammaIncReg(c.k/2, x/2)
+}
+
+// Returns the probability of a value being greater than x
+func (c *ChiSquared) Survival(x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+
+	return mathext.GammaIncRegComp(c.k/2, x/2)
+}
+
+// Returns the value x such that CDF(x) = p. It returns NaN if p is out of range (0 - 1)
+func (c *ChiSquared) Quantile(p float64) float64 {
+	if !validProbability(p) {
+		return math.NaN()
+	}
+
+	return 2 * mathext.GammaIncRegInv(c.k/2, p)
+}
+
+// Returns the mean of the distribution
+func (c *ChiSquared) Mean() float64 {
+	return c.k
+}
+
+// Returns the variance of the distribution
+func (c *ChiSquared) Variance() float64 {
+	return 2 * c.k
+}
+
+// Returns the entropy of the distribution
+func (c *ChiSquared) Entropy() float64 {
+	h := c.k / 2
+	return h + math.Log(2) + mathext.Lgamma(h) + (1-h)*mathext.Digamma(h)
+}
+
+// F represents a Fisher-Snedecor F distribution with d1 and d2 degrees of freedom
+type F struct {
+	d1 float64
+	d2 float64
+
+	source
+}
+
+/*
+NewF returns a new F distribution.
+Degrees of freedom are allowed to be any positive real number.
+It returns an error if d1 or d2 are not greater than 0
+*/
+func NewF(d1, d2 float64) (*F, error) {
+	if !(d1 > 0) || !(d2 > 0) || math.IsInf(d1, 1) || math.IsInf(d2, 1) {
+		return nil, ErrInvalidDegrees
+	}
+
+	return &F{d1: d1, d2: d2}, nil
+}
+
+// Returns the probability density at x
+func (f *F) PDF(x float64) float64 {
+	return math.Exp(f.LogPDF(x))
+}
+
+// Returns the logarithm of the probability density at x
+func (f *F) LogPDF(x float64) float64 {
+	if x < 0 {
+		return math.Inf(-1)
+	}
+
+	if x == 0 {
+		switch {
+		case f.d1 < 2:
+			return math.Inf(1)
+		case f.d1 == 2:
+			return 0
+		default:
+			return math.Inf(-1)
+		}
+	}
+
+	return 0.5*(f.d1*math.Log(f.d1*x)+f.d2*math.Log(f.d2)-(f.d1+f.d2)*math.Log(f.d1*x+f.d2)) -
+		math.Log(x) - mathext.Lbeta(f.d1/2, f.d2/2)
+}
+
+// Returns the probability of a value being less or equal than x
+func (f *F) CDF(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+
+	return mathext.BetaIncReg(f.d1/2, f.d2/2, f.d1*x/(f.d1*x+f.d2))
+}
+
+// Returns the probability of a value being greater than x
+func (f *F) Survival(x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+
+	return mathext.BetaIncReg(f.d2/2, f.d1/2, f.d2/(f.d1*x+f.d2))
+}
+
+// Returns the value x such that CDF(x) = p. It returns NaN if p is out of range (0 - 1)
+func (f *F) Quantile(p float64) float64 {
+	if !validProbability(p) {
+		return math.NaN()
+	}
+
+	y := mathext.BetaIncRegInv(f.d1/2, f.d2/2, p)
+	return f.d2 * y / (f.d1 * (1 - y))
+}
+
+// Returns the mean of the distribution. It is infinite when d2 <= 2
+func (f *F) Mean() float64 {
+	if f.d2 <= 2 {
+		return math.Inf(1)
+	}
+	return f.d2 / (f.d2 - 2)
+}
+
+/*
+Returns the variance of the distribution.
+It is infinite when 2 < d2 <= 4 and undefined (NaN) when d2 <= 2
+*/
+func (f *F) Variance() float64 {
+	switch {
+	case f.d2 > 4:
+		return 2 * f.d2 * f.d2 * (f.d1 + f.d2 - 2) / (f.d1 * (f.d2 - 2) * (f.d2 - 2) * (f.d2 - 4))
+	case f.d2 > 2:
+		return math.Inf(1)
+	default:
+		return math.NaN()
+	}
+}
+
+// Returns the entropy of the distribution
+func (f *F) Entropy() float64 {
+	a, b := f.d1/2, f.d2/2
+	return mathext.Lbeta(a, b) + (1-a)*mathext.Digamma(a) - (1+b)*mathext.Digamma(b) +
+		(a+b)*mathext.Digamma(a+b) + math.Log(f.d2/f.d1)
+}
@@ -0,0 +1,227 @@
+package distuv
+
+import (
+	"math"
+	"testing"
+)
+
+type continuousTest struct {
+	name     string
+	dist     Continuous
+	x        float64
+	expected map[string]float64
+}
+
+func mustContinuous(d Continuous, err error) Continuous {
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+var continuousTests = []continuousTest{
+	{
+		name: "Standard normal",
+		dist: mustContinuous(NewNormal(0, 1)),
+		x:    1.96,
+		expected: map[string]float64{
+			"cdf": 0.9750021048517795, "pdf": 0.05844094433345147,
+			"mean": 0, "variance": 1, "entropy": 1.4189385332046727,
+		},
+	},
+	{
+		name: "Exponential",
+		dist: mustContinuous(NewExponential(2)),
+		x:    1,
+		expected: map[string]float64{
+			"cdf": 1 - math.Exp(-2), "pdf": 2 * math.Exp(-2),
+			"mean": 0.5, "variance": 0.25, "entropy": 1 - math.Log(2),
+		},
+	},
+	{
+		name: "Uniform",
+		dist: mustContinuous(NewUniform(-1, 3)),
+		x:    0,
+		expected: map[string]float64{
+			"cdf": 0.25, "pdf": 0.25,
+			"mean": 1, "variance": 16.0 / 12, "entropy": math.Log(4),
+		},
+	},
+	{
+		name: "Gamma with integer shape",
+		dist: mustContinuous(NewGamma(3, 2)),
+		x:    1.5,
+		expected: map[string]float64{
+			"cdf": 0.5768099188731565, "pdf": 8 * 1.5 * 1.5 * math.Exp(-3) / 2,
+			"mean": 1.5, "variance": 0.75, "entropy": 1.1544313298030653,
+		},
+	},
+	{
+		name: "Gamma with half shape",
+		dist: mustContinuous(NewGamma(0.5, 1)),
+		x:    0.7,
+		expected: map[string]float64{
+			"cdf": 0.7632764293621427, "pdf": math.Exp(-0.7) / math.Sqrt(0.7*math.Pi),
+			"mean": 0.5, "variance": 0.5, "entropy": 0.09060992991398864,
+		},
+	},
+	{
+		name: "Beta",
+		dist: mustContinuous(NewBeta(3, 7)),
+		x:    0.3,
+		expected: map[string]float64{
+			"cdf": 0.5371688339999997, "pdf": 252 * 0.09 * math.Pow(0.7, 6),
+			"mean": 0.3, "variance": 21.0 / 1100, "entropy": -0.5976830557653905,
+		},
+	},
+	{
+		name: "Log-normal",
+		dist: mustContinuous(NewLogNormal(0, 1)),
+		x:    math.E,
+		expected: map[string]float64{
+			"cdf": 0.8413447460685429, "pdf": 0.24197072451914337 / math.E,
+			"mean": math.Exp(0.5), "variance": (math.E - 1) * math.E, "entropy": 1.4189385332046727,
+		},
+	},
+	{
+		name: "Student's t",
+		dist: mustContinuous(NewStudentsT(5)),
+		x:    2,
+		expected: map[string]float64{
+			"cdf": 0.9490302605850709, "pdf": 0.0650903103262164,
+			"mean": 0, "variance": 5.0 / 3, "entropy": 1.6275026724143973,
+		},
+	},
+	{
+		name: "Chi-squared",
+		dist: mustContinuous(NewChiSquared(2)),
+		x:    3,
+		expected: map[string]float64{
+			"cdf": 1 - math.Exp(-1.5), "pdf": math.Exp(-1.5) / 2,
+			"mean": 2, "variance": 4, "entropy": 1 + math.Log(2),
+		},
+	},
+	{
+		name: "F",
+		dist: mustContinuous(NewF(5, 20)),
+		x:    2,
+		expected: map[string]float64{
+			"cdf": 0.8774927553183888, "pdf": 0.15778975100832673,
+			"mean": 10.0 / 9, "variance": 18400.0 / 25920, "entropy": 0.9793893999646348,
+		},
+	},
+}
+
+func TestContinuous(t *testing.T) {
+	e := 1e-10
+	for _, tt := range continuousTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if cdf := tt.dist.CDF(tt.x); math.Abs(cdf-tt.expected["cdf"]) > e {
+				t.Errorf("expected cdf: %v, got:%v", tt.expected["cdf"], cdf)
+			}
+
+			if sf := tt.dist.Survival(tt.x); math.Abs(sf-(1-tt.expected["cdf"])) > e {
+				t.Errorf("expected survival: %v, got:%v", 1-tt.expected["cdf"], sf)
+			}
+
+			if pdf := tt.dist.PDF(tt.x); math.Abs(pdf-tt.expected["pdf"]) > e {
+				t.Errorf("expected pdf: %v, got:%v", tt.expected["pdf"], pdf)
+			}
+
+			if lpdf := tt.dist.LogPDF(tt.x); math.Abs(lpdf-math.Log(tt.expected["pdf"])) > e {
+				t.Errorf("expected log pdf: %v, got:%v", math.Log(tt.expected["pdf"]), lpdf)
+			}
+
+			if q := tt.dist.Quantile(tt.expected["cdf"]); math.Abs(q-tt.x) > 1e-8 {
+				t.Errorf("expected quantile: %v, got:%v", tt.x, q)
+			}
+
+			if mean := tt.dist.Mean(); math.Abs(mean-tt.expected["mean"]) > e {
+				t.Errorf("expected mean: %v, got:%v", tt.expected["mean"], mean)
+			}
+
+			if variance := tt.dist.Variance(); math.Abs(variance-tt.expected["variance"]) > e {
+				t.Errorf("expected variance: %v, got:%v", tt.expected["variance"], variance)
+			}
+
+			if entropy := tt.dist.Entropy(); math.Abs(entropy-tt.expected["entropy"]) > 1e-8 {
+				t.Errorf("expected entropy: %v, got:%v", tt.expected["entropy"], entropy)
+			}
+
+			if q := tt.dist.Quantile(1.5); !math.IsNaN(q) {
+				t.Errorf("expected NaN quantile for invalid probability, got:%v", q)
+			}
+		})
+	}
+}
+
+func TestQuantileRoundTrip(t *testing.T) {
+	probabilities := []float64{1e-6, 0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99, 1 - 1e-6}
+	dists := map[string]Continuous{
+		"Gamma small shape": mustContinuous(NewGamma(0.1, 3)),
+		"Gamma large shape": mustContinuous(NewGamma(250, 0.5)),
+		"Beta U-shaped":     mustContinuous(NewBeta(0.3, 0.4)),
+		"Beta skewed":       mustContinuous(NewBeta(1.5, 40)),
+		"Log-normal":        mustContinuous(NewLogNormal(1, 0.25)),
+		"Student's t":       mustContinuous(NewStudentsT(2.5)),
+		"F":                 mustContinuous(NewF(3, 12)),
+	}
+
+	for name, d := range dists {
+		t.Run(name, func(t *testing.T) {
+			for _, p := range probabilities {
+				x := d.Quantile(p)
+				if cdf := d.CDF(x); math.Abs(cdf-p) > 1e-9*math.Max(1, p/1e-3) {
+					t.Errorf("CDF(Quantile(%v)) = %v", p, cdf)
+				}
+			}
+		})
+	}
+}
+
+func TestChiSquareCriticalValue(t *testing.T) {
+	// Chi-square with 10 degrees of freedom is a Gamma(5, 0.5)
+	g, err := NewGamma(5, 0.5)
+	if err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+
+	expected := 18.307038053275146
+	if q := g.Quantile(0.95); math.Abs(q-expected) > 1e-9 {
+		t.Errorf("expected quantile: %v, got:%v", expected, q)
+	}
+}
+
+func TestInvalidParameters(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"Normal null sigma", second(NewNormal(0, 0)), ErrInvalidScale},
+		{"Normal NaN sigma", second(NewNormal(0, math.NaN())), ErrInvalidScale},
+		{"Normal infinite sigma", second(NewNormal(0, math.Inf(1))), ErrInvalidScale},
+		{"Exponential negative rate", second(NewExponential(-1)), ErrInvalidRate},
+		{"Uniform swapped bounds", second(NewUniform(2, 1)), ErrInvalidBounds},
+		{"Gamma null shape", second(NewGamma(0, 1)), ErrInvalidShape},
+		{"Gamma null rate", second(NewGamma(1, 0)), ErrInvalidRate},
+		{"Beta negative shape", second(NewBeta(1, -1)), ErrInvalidShape},
+		{"LogNormal negative sigma", second(NewLogNormal(0, -1)), ErrInvalidScale},
+		{"LogNormal infinite sigma", second(NewLogNormal(0, math.Inf(1))), ErrInvalidScale},
+		{"StudentsT null degrees of freedom", second(NewStudentsT(0)), ErrInvalidDegrees},
+		{"ChiSquared negative degrees of freedom", second(NewChiSquared(-2)), ErrInvalidDegrees},
+		{"F null denominator degrees of freedom", second(NewF(2, 0)), ErrInvalidDegrees},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err != tt.want {
+				t.Errorf("expected error: %v, got:%v", tt.want, tt.err)
+			}
+		})
+	}
+}
+
+func second[T any](_ T, err error) error {
+	return err
+}
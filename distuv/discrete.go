@@ -0,0 +1,602 @@
+package distuv
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/jaumefe/stats/mathext"
+)
+
+/*
+Discrete represents a univariate probability distribution over the integers:
+  - PMF: probability mass function, P(X = k)
+  - LogPMF: natural logarithm of the probability mass function
+  - CDF: cumulative distribution function, P(X <= k)
+  - Survival: survival function, P(X > k)
+  - Quantile: smallest k such that CDF(k) >= p
+  - Mean and Variance of the distribution
+  - Rand and Sample: random values drawn from the distribution, whose source is set through SetSource
+*/
+type Discrete interface {
+	PMF(k int) float64
+	LogPMF(k int) float64
+	CDF(k int) float64
+	Survival(k int) float64
+	Quantile(p float64) (int, error)
+	Mean() float64
+	Variance() float64
+	Rand() int
+	Sample(n int) []int
+	SetSource(src rand.Source)
+}
+
+var (
+	_ Discrete = (*Bernoulli)(nil)
+	_ Discrete = (*Binomial)(nil)
+	_ Discrete = (*Poisson)(nil)
+	_ Discrete = (*Geometric)(nil)
+	_ Discrete = (*NegativeBinomial)(nil)
+	_ Discrete = (*Hypergeometric)(nil)
+)
+
+// Machine epsilon for float64
+const epsilon = 0x1p-52
+
+// Returns the logarithm of the binomial coefficient n over k
+func lchoose(n, k int) float64 {
+	return mathext.Lgamma(float64(n+1)) - mathext.Lgamma(float64(k+1)) - mathext.Lgamma(float64(n-k+1))
+}
+
+/*
+Returns the smallest k within [lower, upper] such that cdf(k) >= p.
+The search starts at guess and walks towards the answer, so a guess close to it
+(e.g. a normal approximation) keeps the number of CDF evaluations low
+*/
+func discreteQuantile(cdf func(int) float64, p float64, guess, lower, upper int) (int, error) {
+	if !validProbability(p) {
+		return 0, ErrInvalidProbability
+	}
+
+	if p == 0 {
+		return lower, nil
+	}
+	if p == 1 {
+		return upper, nil
+	}
+
+	// Fuzz to avoid stepping one value too far because of rounding in the CDF
+	p *= 1 - 64*epsilon
+
+	k := min(max(guess, lower), upper)
+	if cdf(k) >= p {
+		for k > lower && cdf(k-1) >= p {
+			k--
+		}
+		return k, nil
+	}
+
+	for k < upper && cdf(k) < p {
+		k++
+	}
+	return k, nil
+}
+
+// Returns a normal approximation to the p-quantile, used as a starting point for discreteQuantile
+func normalGuess(mean, variance, p float64) int {
+	z := -math.Sqrt2 * math.Erfcinv(2*p)
+	guess := math.Floor(mean + math.Sqrt(variance)*z)
+	if math.IsNaN(guess) || math.IsInf(guess, 0) {
+		return int(mean)
+	}
+
+	return int(max(min(guess, math.MaxInt32), math.MinInt32))
+}
+
+// Bernoulli represents a single trial with a probability p of success (1) and 1-p of failure (0)
+type Bernoulli struct {
+	p float64
+
+	source
+}
+
+/*
+NewBernoulli returns a new Bernoulli distribution.
+It returns an error if p is out of range (0 - 1)
+*/
+func NewBernoulli(p float64) (*Bernoulli, error) {
+	if !validProbability(p) {
+		return nil, ErrInvalidProbability
+	}
+
+	return &Bernoulli{p: p}, nil
+}
+
+// Returns the probability of k
+func (b *Bernoulli) PMF(k int) float64 {
+	switch k {
+	case 0:
+		return 1 - b.p
+	case 1:
+		return b.p
+	default:
+		return 0
+	}
+}
+
+// Returns the logarithm of the probability of k
+func (b *Bernoulli) LogPMF(k int) float64 {
+	return math.Log(b.PMF(k))
+}
+
+// Returns the probability of a value being less or equal than k
+func (b *Bernoulli) CDF(k int) float64 {
+	switch {
+	case k < 0:
+		return 0
+	case k == 0:
+		return 1 - b.p
+	default:
+		return 1
+	}
+}
+
+// Returns the probability of a value being greater than k
+func (b *Bernoulli) Survival(k int) float64 {
+	switch {
+	case k < 0:
+		return 1
+	case k == 0:
+		return b.p
+	default:
+		return 0
+	}
+}
+
+// Returns the smallest k such that CDF(k) >= p. It returns an error if p is out of range (0 - 1)
+func (b *Bernoulli) Quantile(p float64) (int, error) {
+	return discreteQuantile(b.CDF, p, 0, 0, 1)
+}
+
+// Returns the mean of the distribution
+func (b *Bernoulli) Mean() float64 {
+	return b.p
+}
+
+// Returns the variance of the distribution
+func (b *Bernoulli) Variance() float64 {
+	return b.p * (1 - b.p)
+}
+
+// Binomial represents the number of successes in n independent trials with probability p of success
+type Binomial struct {
+	n int
+	p float64
+
+	source
+}
+
+/*
+NewBinomial returns a new Binomial distribution.
+It returns an error if n is negative or p is out of range (0 - 1)
+*/
+func NewBinomial(n int, p float64) (*Binomial, error) {
+	if n < 0 {
+		return nil, ErrInvalidTrials
+	}
+
+	if !validProbability(p) {
+		return nil, ErrInvalidProbability
+	}
+
+	return &Binomial{n: n, p: p}, nil
+}
+
+// Returns the probability of k
+func (b *Binomial) PMF(k int) float64 {
+	return math.Exp(b.LogPMF(k))
+}
+
+// Returns the logarithm of the probability of k
+func (b *Binomial) LogPMF(k int) float64 {
+	if k < 0 || k > b.n {
+		return math.Inf(-1)
+	}
+
+	switch b.p {
+	case 0:
+		if k == 0 {
+			return 0
+		}
+		return math.Inf(-1)
+	case 1:
+		if k == b.n {
+			return 0
+		}
+		return math.Inf(-1)
+	}
+
+	return lchoose(b.n, k) + float64(k)*math.Log(b.p) + float64(b.n-k)*math.Log1p(-b.p)
+}
+
+// Returns the probability of a value being less or equal than k
+func (b *Binomial) CDF(k int) float64 {
+	if k < 0 {
+		return 0
+	}
+	if k >= b.n {
+		return 1
+	}
+
+	return mathext.BetaIncReg(float64(b.n-k), float64(k+1), 1-b.p)
+}
+
+// Returns the probability of a value being greater than k
+func (b *Binomial) Survival(k int) float64 {
+	if k < 0 {
+		return 1
+	}
+	if k >= b.n {
+		return 0
+	}
+
+	return mathext.BetaIncReg(float64(k+1), float64(b.n-k), b.p)
+}
+
+// Returns the smallest k such that CDF(k) >= p. It returns an error if p is out of range (0 - 1)
+func (b *Binomial) Quantile(p float64) (int, error) {
+	return discreteQuantile(b.CDF, p, normalGuess(b.Mean(), b.Variance(), p), 0, b.n)
+}
+
+// Returns the mean of the distribution
+func (b *Binomial) Mean() float64 {
+	return float64(b.n) * b.p
+}
+
+// Returns the variance of the distribution
+func (b *Binomial) Variance() float64 {
+	return float64(b.n) * b.p * (1 - b.p)
+}
+
+// Poisson represents the number of events occurring in a fixed interval given their mean rate lambda
+type Poisson struct {
+	lambda float64
+
+	source
+}
+
+/*
+NewPoisson returns a new Poisson distribution.
+It returns an error if lambda is not greater than 0
+*/
+func NewPoisson(lambda float64) (*Poisson, error) {
+	if !(lambda > 0) || math.IsInf(lambda, 1) {
+		return nil, ErrInvalidRate
+	}
+
+	return &Poisson{lambda: lambda}, nil
+}
+
+// Returns the probability of k
+func (p *Poisson) PMF(k int) float64 {
+	return math.Exp(p.LogPMF(k))
+}
+
+// Returns the logarithm of the probability of k
+func (p *Poisson) LogPMF(k int) float64 {
+	if k < 0 {
+		return math.Inf(-1)
+	}
+
+	return float64(k)*math.Log(p.lambda) - p.lambda - mathext.Lgamma(float64(k+1))
+}
+
+// Returns the probability of a value being less or equal than k
+func (p *Poisson) CDF(k int) float64 {
+	if k < 0 {
+		return 0
+	}
+
+	return mathext.GammaIncRegComp(float64(k+1), p.lambda)
+}
+
+// Returns the probability of a value being greater than k
+func (p *Poisson) Survival(k int) float64 {
+	if k < 0 {
+		return 1
+	}
+
+	return mathext.GammaIncReg(float64(k+1), p.lambda)
+}
+
+/*
+Returns the smallest k such that CDF(k) >= q. It returns an error if q is out of range (0 - 1).
+As the support is unbounded, math.MaxInt is returned when q is 1
+*/
+func (p *Poisson) Quantile(q float64) (int, error) {
+	return discreteQuantile(p.CDF, q, normalGuess(p.lambda, p.lambda, q), 0, math.MaxInt)
+}
+
+// Returns the mean of the distribution
+func (p *Poisson) Mean() float64 {
+	return p.lambda
+}
+
+// Returns the variance of the distribution
+func (p *Poisson) Variance() float64 {
+	return p.lambda
+}
+
+/*
+Geometric represents the number of failures before the first success in
+independent trials with probability p of success
+*/
+type Geometric struct {
+	p float64
+
+	source
+}
+
+/*
+NewGeometric returns a new Geometric distribution.
+It returns an error if p is not in the range (0 - 1]
+*/
+func NewGeometric(p float64) (*Geometric, error) {
+	if !(p > 0 && p <= 1) {
+		return nil, ErrInvalidProbability
+	}
+
+	return &Geometric{p: p}, nil
+}
+
+// Returns the probability of k
+func (g *Geometric) PMF(k int) float64 {
+	return math.Exp(g.LogPMF(k))
+}
+
+// Returns the logarithm of the probability of k
+func (g *Geometric) LogPMF(k int) float64 {
+	if k < 0 {
+		return math.Inf(-1)
+	}
+
+	if g.p == 1 {
+		if k == 0 {
+			return 0
+		}
+		return math.Inf(-1)
+	}
+
+	return math.Log(g.p) + float64(k)*math.Log1p(-g.p)
+}
+
+// Returns the probability of a value being less or equal than k
+func (g *Geometric) CDF(k int) float64 {
+	if k < 0 {
+		return 0
+	}
+
+	return -math.Expm1(float64(k+1) * math.Log1p(-g.p))
+}
+
+// Returns the probability of a value being greater than k
+func (g *Geometric) Survival(k int) float64 {
+	if k < 0 {
+		return 1
+	}
+
+	return math.Exp(float64(k+1) * math.Log1p(-g.p))
+}
+
+/*
+Returns the smallest k such that CDF(k) >= p. It returns an error if p is out of range (0 - 1).
+As the support is unbounded, math.MaxInt is returned when p is 1
+*/
+func (g *Geometric) Quantile(p float64) (int, error) {
+	guess := 0
+	if g.p < 1 && p > 0 && p < 1 {
+		guess = int(min(math.Ceil(math.Log1p(-p)/math.Log1p(-g.p))-1, math.MaxInt32))
+	}
+
+	return discreteQuantile(g.CDF, p, guess, 0, math.MaxInt)
+}
+
+// Returns the mean of the distribution
+func (g *Geometric) Mean() float64 {
+	return (1 - g.p) / g.p
+}
+
+// Returns the variance of the distribution
+func (g *Geometric) Variance() float64 {
+	return (1 - g.p) / (g.p * g.p)
+}
+
+/*
+NegativeBinomial represents the number of failures before r successes in
+independent trials with probability p of success.
+r is allowed to be any positive real number
+*/
+type NegativeBinomial struct {
+	r float64
+	p float64
+
+	source
+}
+
+/*
+NewNegativeBinomial returns a new NegativeBinomial distribution.
+It returns an error if r is not greater than 0 or p is not in the range (0 - 1]
+*/
+func NewNegativeBinomial(r, p float64) (*NegativeBinomial, error) {
+	if !(r > 0) || math.IsInf(r, 1) {
+		return nil, ErrInvalidCount
+	}
+
+	if !(p > 0 && p <= 1) {
+		return nil, ErrInvalidProbability
+	}
+
+	return &NegativeBinomial{r: r, p: p}, nil
+}
+
+// Returns the probability of k
+func (nb *NegativeBinomial) PMF(k int) float64 {
+	return math.Exp(nb.LogPMF(k))
+}
+
+// Returns the logarithm of the probability of k
+func (nb *NegativeBinomial) LogPMF(k int) float64 {
+	if k < 0 {
+		return math.Inf(-1)
+	}
+
+	if nb.p == 1 {
+		if k == 0 {
+			return 0
+		}
+		return math.Inf(-1)
+	}
+
+	fk := float64(k)
+	return mathext.Lgamma(fk+nb.r) - mathext.Lgamma(fk+1) - mathext.Lgamma(nb.r) + nb.r*math.Log(nb.p) + fk*math.Log1p(-nb.p)
+}
+
+// Returns the probability of a value being less or equal than k
+func (nb *NegativeBinomial) CDF(k int) float64 {
+	if k < 0 {
+		return 0
+	}
+
+	return mathext.BetaIncReg(nb.r, float64(k+1), nb.p)
+}
+
+// Returns the probability of a value being greater than k
+func (nb *NegativeBinomial) Survival(k int) float64 {
+	if k < 0 {
+		return 1
+	}
+
+	return mathext.BetaIncReg(float64(k+1), nb.r, 1-nb.p)
+}
+
+/*
+Returns the smallest k such that CDF(k) >= p. It returns an error if p is out of range (0 - 1).
+As the support is unbounded, math.MaxInt is returned when p is 1
+*/
+func (nb *NegativeBinomial) Quantile(p float64) (int, error) {
+	return discreteQuantile(nb.CDF, p, normalGuess(nb.Mean(), nb.Variance(), p), 0, math.MaxInt)
+}
+
+// Returns the mean of the distribution
+func (nb *NegativeBinomial) Mean() float64 {
+	return nb.r * (1 - nb.p) / nb.p
+}
+
+// Returns the variance of the distribution
+func (nb *NegativeBinomial) Variance() float64 {
+	return nb.r * (1 - nb.p) / (nb.p * nb.p)
+}
+
+/*
+Hypergeometric represents the number of successes in a given number of draws,
+without replacement, from a finite population containing a given number of successes
+*/
+type Hypergeometric struct {
+	population int
+	successes  int
+	draws      int
+
+	source
+}
+
+/*
+NewHypergeometric returns a new Hypergeometric distribution.
+It returns an error if successes or draws are out of range (0 - population)
+*/
+func NewHypergeometric(population, successes, draws int) (*Hypergeometric, error) {
+	if population < 0 || successes < 0 || successes > population || draws < 0 || draws > population {
+		return nil, ErrInvalidPopulation
+	}
+
+	return &Hypergeometric{population: population, successes: successes, draws: draws}, nil
+}
+
+// Returns the smallest and the largest values with non null probability
+func (h *Hypergeometric) support() (int, int) {
+	return max(0, h.draws+h.successes-h.population), min(h.draws, h.successes)
+}
+
+// Returns the probability of k
+func (h *Hypergeometric) PMF(k int) float64 {
+	return math.Exp(h.LogPMF(k))
+}
+
+// Returns the logarithm of the probability of k
+func (h *Hypergeometric) LogPMF(k int) float64 {
+	lower, upper := h.support()
+	if k < lower || k > upper {
+		return math.Inf(-1)
+	}
+
+	return lchoose(h.successes, k) + lchoose(h.population-h.successes, h.draws-k) - lchoose(h.population, h.draws)
+}
+
+// Returns the probability of a value being less or equal than k
+func (h *Hypergeometric) CDF(k int) float64 {
+	lower, upper := h.support()
+	if k < lower {
+		return 0
+	}
+	if k >= upper {
+		return 1
+	}
+
+	sum := 0.0
+	for i := lower; i <= k; i++ {
+		sum += h.PMF(i)
+	}
+	return min(sum, 1)
+}
+
+// Returns the probability of a value being greater than k
+func (h *Hypergeometric) Survival(k int) float64 {
+	lower, upper := h.support()
+	if k < lower {
+		return 1
+	}
+	if k >= upper {
+		return 0
+	}
+
+	sum := 0.0
+	for i := k + 1; i <= upper; i++ {
+		sum += h.PMF(i)
+	}
+	return min(sum, 1)
+}
+
+// Returns the smallest k such that CDF(k) >= p. It returns an error if p is out of range (0 - 1)
+func (h *Hypergeometric) Quantile(p float64) (int, error) {
+	lower, upper := h.support()
+	return discreteQuantile(h.CDF, p, normalGuess(h.Mean(), h.Variance(), p), lower, upper)
+}
+
+// Returns the mean of the distribution
+func (h *Hypergeometric) Mean() float64 {
+	if h.population == 0 {
+		return 0
+	}
+
+	return float64(h.draws) * float64(h.successes) / float64(h.population)
+}
+
+// Returns the variance of the distribution
+func (h *Hypergeometric) Variance() float64 {
+	n := float64(h.population)
+	if n <= 1 {
+		return 0
+	}
+
+	k := float64(h.successes)
+	d := float64(h.draws)
+	return d * (k / n) * ((n - k) / n) * ((n - d) / (n - 1))
+}
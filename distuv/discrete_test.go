@@ -0,0 +1,171 @@
+package distuv
+
+import (
+	"math"
+	"testing"
+)
+
+type discreteTest struct {
+	name     string
+	dist     Discrete
+	k        int
+	expected map[string]float64
+}
+
+func mustDiscrete(d Discrete, err error) Discrete {
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+var discreteTests = []discreteTest{
+	{
+		name: "Bernoulli",
+		dist: mustDiscrete(NewBernoulli(0.3)),
+		k:    0,
+		expected: map[string]float64{
+			"pmf": 0.7, "cdf": 0.7, "mean": 0.3, "variance": 0.21,
+		},
+	},
+	{
+		name: "Binomial",
+		dist: mustDiscrete(NewBinomial(10, 0.3)),
+		k:    3,
+		expected: map[string]float64{
+			"pmf": 0.2668279319999998, "cdf": 0.6496107183999996, "mean": 3, "variance": 2.1,
+		},
+	},
+	{
+		name: "Poisson",
+		dist: mustDiscrete(NewPoisson(4)),
+		k:    6,
+		expected: map[string]float64{
+			"pmf": 0.1041956345670211, "cdf": 0.8893260215974261, "mean": 4, "variance": 4,
+		},
+	},
+	{
+		name: "Geometric",
+		dist: mustDiscrete(NewGeometric(0.25)),
+		k:    3,
+		expected: map[string]float64{
+			"pmf": 0.10546875, "cdf": 0.68359375, "mean": 3, "variance": 12,
+		},
+	},
+	{
+		name: "Negative binomial",
+		dist: mustDiscrete(NewNegativeBinomial(2.5, 0.4)),
+		k:    3,
+		expected: map[string]float64{
+			"pmf": 0.14344091466523762, "cdf": 0.5558019215511942, "mean": 3.75, "variance": 9.375,
+		},
+	},
+	{
+		name: "Hypergeometric",
+		dist: mustDiscrete(NewHypergeometric(50, 15, 10)),
+		k:    4,
+		expected: map[string]float64{
+			"pmf": 0.21568860999799036, "cdf": 0.8750953047837878, "mean": 3, "variance": 1.7142857142857142,
+		},
+	},
+}
+
+func TestDiscrete(t *testing.T) {
+	e := 1e-10
+	for _, tt := range discreteTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if pmf := tt.dist.PMF(tt.k); math.Abs(pmf-tt.expected["pmf"]) > e {
+				t.Errorf("expected pmf: %v, got:%v", tt.expected["pmf"], pmf)
+			}
+
+			if lpmf := tt.dist.LogPMF(tt.k); math.Abs(lpmf-math.Log(tt.expected["pmf"])) > e {
+				t.Errorf("expected log pmf: %v, got:%v", math.Log(tt.expected["pmf"]), lpmf)
+			}
+
+			if cdf := tt.dist.CDF(tt.k); math.Abs(cdf-tt.expected["cdf"]) > e {
+				t.Errorf("expected cdf: %v, got:%v", tt.expected["cdf"], cdf)
+			}
+
+			if sf := tt.dist.Survival(tt.k); math.Abs(sf-(1-tt.expected["cdf"])) > e {
+				t.Errorf("expected survival: %v, got:%v", 1-tt.expected["cdf"], sf)
+			}
+
+			if mean := tt.dist.Mean(); math.Abs(mean-tt.expected["mean"]) > e {
+				t.Errorf("expected mean: %v, got:%v", tt.expected["mean"], mean)
+			}
+
+			if variance := tt.dist.Variance(); math.Abs(variance-tt.expected["variance"]) > e {
+				t.Errorf("expected variance: %v, got:%v", tt.expected["variance"], variance)
+			}
+
+			// The quantile of the CDF at k must be k, and slightly above it must be k + 1
+			if q, err := tt.dist.Quantile(tt.expected["cdf"]); err != nil || q != tt.k {
+				t.Errorf("expected quantile: %v, got:%v (%v)", tt.k, q, err)
+			}
+
+			if q, err := tt.dist.Quantile(tt.expected["cdf"] + 1e-6); err != nil || q != tt.k+1 {
+				t.Errorf("expected quantile: %v, got:%v (%v)", tt.k+1, q, err)
+			}
+
+			if _, err := tt.dist.Quantile(-0.1); err != ErrInvalidProbability {
+				t.Errorf("expected error: %v, got:%v", ErrInvalidProbability, err)
+			}
+		})
+	}
+}
+
+func TestDiscreteMassSumsToOne(t *testing.T) {
+	for _, tt := range discreteTests {
+		t.Run(tt.name, func(t *testing.T) {
+			sum := 0.0
+			for k := 0; k < 500; k++ {
+				sum += tt.dist.PMF(k)
+			}
+
+			if math.Abs(sum-1) > 1e-10 {
+				t.Errorf("expected total mass: 1, got:%v", sum)
+			}
+		})
+	}
+}
+
+func TestDiscreteQuantileLargeMean(t *testing.T) {
+	p, err := NewPoisson(1e6)
+	if err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+
+	for _, q := range []float64{0.001, 0.5, 0.999} {
+		k, err := p.Quantile(q)
+		if err != nil {
+			t.Fatalf("unexpected error received: %v", err)
+		}
+
+		if p.CDF(k) < q || p.CDF(k-1) >= q {
+			t.Errorf("quantile %v is not the smallest value with CDF >= %v", k, q)
+		}
+	}
+}
+
+func TestInvalidDiscreteParameters(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"Bernoulli probability above 1", second(NewBernoulli(1.1)), ErrInvalidProbability},
+		{"Binomial negative trials", second(NewBinomial(-1, 0.5)), ErrInvalidTrials},
+		{"Poisson null rate", second(NewPoisson(0)), ErrInvalidRate},
+		{"Geometric null probability", second(NewGeometric(0)), ErrInvalidProbability},
+		{"Negative binomial null count", second(NewNegativeBinomial(0, 0.5)), ErrInvalidCount},
+		{"Hypergeometric too many draws", second(NewHypergeometric(10, 3, 11)), ErrInvalidPopulation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err != tt.want {
+				t.Errorf("expected error: %v, got:%v", tt.want, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,6 @@
+/*
+Package distuv provides univariate probability distributions, both continuous and
+discrete: density (or mass), cumulative distribution and quantile functions, their
+moments and random variate generation from a pluggable source.
+*/
+package distuv
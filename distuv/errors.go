@@ -0,0 +1,15 @@
+package distuv
+
+import "errors"
+
+var (
+	ErrInvalidScale       = errors.New("scale parameter must be greater than 0")
+	ErrInvalidShape       = errors.New("shape parameter must be greater than 0")
+	ErrInvalidRate        = errors.New("rate parameter must be greater than 0")
+	ErrInvalidBounds      = errors.New("lower bound must be less than upper bound")
+	ErrInvalidProbability = errors.New("probability must be between 0 and 1")
+	ErrInvalidTrials      = errors.New("number of trials must be greater or equal than 0")
+	ErrInvalidCount       = errors.New("count parameter must be greater than 0")
+	ErrInvalidPopulation  = errors.New("successes and draws must be between 0 and the population size")
+	ErrInvalidDegrees     = errors.New("degrees of freedom must be greater than 0")
+)
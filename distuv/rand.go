@@ -0,0 +1,336 @@
+package distuv
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/jaumefe/stats/mathext"
+)
+
+/*
+NewSource returns a new random source seeded with seed.
+As in shuffle.ShuffleOptions, a null seed means the current time is used as seed
+*/
+func NewSource(seed int64) rand.Source {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	return rand.NewSource(seed)
+}
+
+/*
+source holds the random number generator used by a distribution to draw samples.
+It is not safe for concurrent use: give each goroutine its own distribution or source
+*/
+type source struct {
+	rnd *rand.Rand
+}
+
+/*
+SetSource sets the random source used by Rand and Sample.
+If no source is set, one seeded with the current time is used
+*/
+func (s *source) SetSource(src rand.Source) {
+	s.rnd = rand.New(src)
+}
+
+// Returns the random number generator, creating a time seeded one if none was set
+func (s *source) generator() *rand.Rand {
+	if s.rnd == nil {
+		s.rnd = rand.New(NewSource(0))
+	}
+	return s.rnd
+}
+
+// Returns a sample of n values drawn by draw. It returns nil if n is not positive
+func sample[T int | float64](n int, draw func() T) []T {
+	if n <= 0 {
+		return nil
+	}
+
+	s := make([]T, n)
+	for i := range s {
+		s[i] = draw()
+	}
+	return s
+}
+
+/*
+Draws a value from a Gamma(shape, 1) distribution through Marsaglia and Tsang's method.
+Shapes lower than 1 are boosted to shape + 1 and scaled back with a uniform power
+*/
+func gammaRand(r *rand.Rand, shape float64) float64 {
+	if shape < 1 {
+		u := r.Float64()
+		for u == 0 {
+			u = r.Float64()
+		}
+		return gammaRand(r, shape+1) * math.Pow(u, 1/shape)
+	}
+
+	d := shape - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := r.NormFloat64()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+
+		v = v * v * v
+		u := r.Float64()
+		if u < 1-0.0331*x*x*x*x {
+			return d * v
+		}
+		if math.Log(u) < 0.5*x*x+d*(1-v+math.Log(v)) {
+			return d * v
+		}
+	}
+}
+
+/*
+Draws a value from a Poisson(lambda) distribution.
+Small means use the multiplication method and large ones Hörmann's transformed rejection (PTRS)
+*/
+func poissonRand(r *rand.Rand, lambda float64) int {
+	if lambda <= 0 {
+		return 0
+	}
+
+	if lambda < 10 {
+		limit := math.Exp(-lambda)
+		k := 0
+		prod := r.Float64()
+		for prod > limit {
+			k++
+			prod *= r.Float64()
+		}
+		return k
+	}
+
+	slam := math.Sqrt(lambda)
+	loglam := math.Log(lambda)
+	b := 0.931 + 2.53*slam
+	a := -0.059 + 0.02483*b
+	invAlpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+	for {
+		u := r.Float64() - 0.5
+		v := r.Float64()
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + lambda + 0.43)
+		if us >= 0.07 && v <= vr {
+			return int(k)
+		}
+		if k < 0 || (us < 0.013 && v > us) {
+			continue
+		}
+		if math.Log(v)+math.Log(invAlpha)-math.Log(a/(us*us)+b) <= -lambda+k*loglam-mathext.Lgamma(k+1) {
+			return int(k)
+		}
+	}
+}
+
+// Returns a random value drawn from the distribution
+func (n *Normal) Rand() float64 {
+	return n.mu + n.sigma*n.generator().NormFloat64()
+}
+
+// Returns size random values drawn from the distribution
+func (n *Normal) Sample(size int) []float64 {
+	return sample(size, n.Rand)
+}
+
+// Returns a random value drawn from the distribution
+func (e *Exponential) Rand() float64 {
+	return e.generator().ExpFloat64() / e.rate
+}
+
+// Returns n random values drawn from the distribution
+func (e *Exponential) Sample(n int) []float64 {
+	return sample(n, e.Rand)
+}
+
+// Returns a random value drawn from the distribution
+func (u *Uniform) Rand() float64 {
+	return u.min + u.generator().Float64()*(u.max-u.min)
+}
+
+// Returns n random values drawn from the distribution
+func (u *Uniform) Sample(n int) []float64 {
+	return sample(n, u.Rand)
+}
+
+// Returns a random value drawn from the distribution
+func (g *Gamma) Rand() float64 {
+	return gammaRand(g.generator(), g.shape) / g.rate
+}
+
+// Returns n random values drawn from the distribution
+func (g *Gamma) Sample(n int) []float64 {
+	return sample(n, g.Rand)
+}
+
+// Returns a random value drawn from the distribution
+func (b *Beta) Rand() float64 {
+	r := b.generator()
+	x := gammaRand(r, b.alpha)
+	y := gammaRand(r, b.beta)
+	return x / (x + y)
+}
+
+// Returns n random values drawn from the distribution
+func (b *Beta) Sample(n int) []float64 {
+	return sample(n, b.Rand)
+}
+
+// Returns a random value drawn from the distribution
+func (l *LogNormal) Rand() float64 {
+	return math.Exp(l.mu + l.sigma*l.generator().NormFloat64())
+}
+
+// Returns n random values drawn from the distribution
+func (l *LogNormal) Sample(n int) []float64 {
+	return sample(n, l.Rand)
+}
+
+// Returns a random value drawn from the distribution as a normal over the root of a scaled chi-square
+func (s *StudentsT) Rand() float64 {
+	r := s.generator()
+	return r.NormFloat64() / math.Sqrt(2*gammaRand(r, s.nu/2)/s.nu)
+}
+
+// Returns n random values drawn from the distribution
+func (s *StudentsT) Sample(n int) []float64 {
+	return sample(n, s.Rand)
+}
+
+// Returns a random value drawn from the distribution
+func (c *ChiSquared) Rand() float64 {
+	return 2 * gammaRand(c.generator(), c.k/2)
+}
+
+// Returns n random values drawn from the distribution
+func (c *ChiSquared) Sample(n int) []float64 {
+	return sample(n, c.Rand)
+}
+
+// Returns a random value drawn from the distribution as the ratio of two scaled chi-squares
+func (f *F) Rand() float64 {
+	r := f.generator()
+	return gammaRand(r, f.d1/2) * f.d2 / (gammaRand(r, f.d2/2) * f.d1)
+}
+
+// Returns n random values drawn from the distribution
+func (f *F) Sample(n int) []float64 {
+	return sample(n, f.Rand)
+}
+
+// Returns a random value drawn from the distribution
+func (b *Bernoulli) Rand() int {
+	if b.generator().Float64() < b.p {
+		return 1
+	}
+	return 0
+}
+
+// Returns n random values drawn from the distribution
+func (b *Bernoulli) Sample(n int) []int {
+	return sample(n, b.Rand)
+}
+
+/*
+Returns a random value drawn from the distribution.
+Few expected successes (or failures) are drawn by adding geometric waiting times,
+otherwise the CDF is inverted
+*/
+func (b *Binomial) Rand() int {
+	r := b.generator()
+	p := math.Min(b.p, 1-b.p)
+	if p == 0 {
+		return int(math.Round(b.p)) * b.n
+	}
+
+	if float64(b.n)*p < 30 {
+		k := -1
+		rate := -math.Log1p(-p)
+		for trials := 0.0; trials <= float64(b.n); k++ {
+			trials += math.Floor(r.ExpFloat64()/rate) + 1
+		}
+		if p != b.p {
+			return b.n - k
+		}
+		return k
+	}
+
+	k, _ := b.Quantile(r.Float64())
+	return k
+}
+
+// Returns n random values drawn from the distribution
+func (b *Binomial) Sample(n int) []int {
+	return sample(n, b.Rand)
+}
+
+// Returns a random value drawn from the distribution
+func (p *Poisson) Rand() int {
+	return poissonRand(p.generator(), p.lambda)
+}
+
+// Returns n random values drawn from the distribution
+func (p *Poisson) Sample(n int) []int {
+	return sample(n, p.Rand)
+}
+
+// Returns a random value drawn from the distribution
+func (g *Geometric) Rand() int {
+	if g.p == 1 {
+		return 0
+	}
+
+	return int(math.Floor(g.generator().ExpFloat64() / -math.Log1p(-g.p)))
+}
+
+// Returns n random values drawn from the distribution
+func (g *Geometric) Sample(n int) []int {
+	return sample(n, g.Rand)
+}
+
+// Returns a random value drawn from the distribution as a gamma-Poisson mixture
+func (nb *NegativeBinomial) Rand() int {
+	if nb.p == 1 {
+		return 0
+	}
+
+	r := nb.generator()
+	lambda := gammaRand(r, nb.r) * (1 - nb.p) / nb.p
+	return poissonRand(r, lambda)
+}
+
+// Returns n random values drawn from the distribution
+func (nb *NegativeBinomial) Sample(n int) []int {
+	return sample(n, nb.Rand)
+}
+
+// Returns a random value drawn from the distribution by simulating each draw
+func (h *Hypergeometric) Rand() int {
+	r := h.generator()
+	successes := h.successes
+	remaining := h.population
+	k := 0
+	for i := 0; i < h.draws; i++ {
+		if r.Intn(remaining) < successes {
+			successes--
+			k++
+		}
+		remaining--
+	}
+	return k
+}
+
+// Returns n random values drawn from the distribution
+func (h *Hypergeometric) Sample(n int) []int {
+	return sample(n, h.Rand)
+}
@@ -0,0 +1,108 @@
+package distuv
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+const sampleSize = 200000
+
+// Returns the mean and variance of a sample
+func sampleMoments(data []float64) (float64, float64) {
+	mean := 0.0
+	for _, v := range data {
+		mean += v
+	}
+	mean /= float64(len(data))
+
+	variance := 0.0
+	for _, v := range data {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, variance / float64(len(data))
+}
+
+// Checks that the sample moments agree with the distribution ones within a few standard errors
+func checkMoments(t *testing.T, data []float64, mean, variance float64) {
+	t.Helper()
+
+	sMean, sVariance := sampleMoments(data)
+	if se := math.Sqrt(variance / float64(len(data))); math.Abs(sMean-mean) > 5*se {
+		t.Errorf("expected sample mean: %v, got:%v", mean, sMean)
+	}
+
+	if math.Abs(sVariance-variance) > 0.05*variance {
+		t.Errorf("expected sample variance: %v, got:%v", variance, sVariance)
+	}
+}
+
+func TestContinuousRand(t *testing.T) {
+	for _, tt := range continuousTests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.dist.SetSource(NewSource(1))
+			data := tt.dist.Sample(sampleSize)
+			if len(data) != sampleSize {
+				t.Fatalf("expected sample size: %v, got:%v", sampleSize, len(data))
+			}
+
+			checkMoments(t, data, tt.dist.Mean(), tt.dist.Variance())
+		})
+	}
+}
+
+func TestDiscreteRand(t *testing.T) {
+	tests := append([]discreteTest{
+		{name: "Binomial inverting the CDF", dist: mustDiscrete(NewBinomial(400, 0.4))},
+		{name: "Binomial with high probability", dist: mustDiscrete(NewBinomial(20, 0.9))},
+		{name: "Poisson with large mean", dist: mustDiscrete(NewPoisson(250))},
+	}, discreteTests...)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.dist.SetSource(NewSource(1))
+			sample := tt.dist.Sample(sampleSize)
+			if len(sample) != sampleSize {
+				t.Fatalf("expected sample size: %v, got:%v", sampleSize, len(sample))
+			}
+
+			data := make([]float64, len(sample))
+			for i, k := range sample {
+				data[i] = float64(k)
+			}
+			checkMoments(t, data, tt.dist.Mean(), tt.dist.Variance())
+		})
+	}
+}
+
+func TestRandReproducibility(t *testing.T) {
+	g1, _ := NewGamma(0.5, 2)
+	g2, _ := NewGamma(0.5, 2)
+	g1.SetSource(NewSource(42))
+	g2.SetSource(NewSource(42))
+
+	if s1, s2 := g1.Sample(100), g2.Sample(100); !reflect.DeepEqual(s1, s2) {
+		t.Errorf("samples drawn with the same seed differ: %v, %v", s1, s2)
+	}
+
+	p1, _ := NewPoisson(3)
+	p2, _ := NewPoisson(3)
+	p1.SetSource(NewSource(42))
+	p2.SetSource(NewSource(42))
+
+	if s1, s2 := p1.Sample(100), p2.Sample(100); !reflect.DeepEqual(s1, s2) {
+		t.Errorf("samples drawn with the same seed differ: %v, %v", s1, s2)
+	}
+}
+
+func TestSampleEmpty(t *testing.T) {
+	n, _ := NewNormal(0, 1)
+	if s := n.Sample(0); s != nil {
+		t.Errorf("expected nil sample, got:%v", s)
+	}
+
+	b, _ := NewBernoulli(0.5)
+	if s := b.Sample(-1); s != nil {
+		t.Errorf("expected nil sample, got:%v", s)
+	}
+}
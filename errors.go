@@ -10,4 +10,7 @@ var (
 	ErrInvalidPercentile = errors.New("percentile must be between 0 and 100")
 	ErrInvalideQuantile  = errors.New("quantile must be between 0 and maximum quantile number")
 	ErrInvalidLogBase    = errors.New("logarithm base must be greater than 0 and not equal to 1")
+	ErrInvalidWeight     = errors.New("weight must be a finite value greater than 0")
+	ErrNaNInData         = errors.New("data contains NaN values")
+	ErrNotEnoughData     = errors.New("not enough data for the estimator")
 )
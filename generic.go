@@ -0,0 +1,201 @@
+package stats
+
+/*
+Number is the constraint of the numeric types accepted by the generic functions,
+such as MeanOf or MedianOf. Integers beyond 2^53 lose precision when converted to float64.
+Generic functions use the default options, as the functions without options do: NaN values of
+floating point types are always propagated. Convert data with Float64s to use the With functions instead
+*/
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+/*
+Float64s returns the values of a []T data input as a []float64.
+A []float64 input is returned as it is, without copying it
+*/
+func Float64s[T Number](data []T) []float64 {
+	if f, ok := any(data).([]float64); ok {
+		return f
+	}
+
+	res := make([]float64, len(data))
+	for i, v := range data {
+		res[i] = float64(v)
+	}
+	return res
+}
+
+/*
+SumOf computes the sum of all the elements of a []T data input as a float64.
+*/
+func SumOf[T Number](data []T) float64 {
+	return Sum(Float64s(data))
+}
+
+/*
+MeanOf computes the mean value of a []T data input.
+It returns an error if the data is empty
+*/
+func MeanOf[T Number](data []T) (float64, error) {
+	return Mean(Float64s(data))
+}
+
+/*
+MedianOf computes the median value of a []T data input.
+It returns an error if the data is empty
+*/
+func MedianOf[T Number](data []T) (float64, error) {
+	return Median(Float64s(data))
+}
+
+/*
+ModeOf provides the mode value of a []T data input.
+It returns an error if the data is empty
+*/
+func ModeOf[T Number](data []T) (T, error) {
+	mode, err := Mode(Float64s(data))
+	return T(mode), err
+}
+
+/*
+VarianceOf computes the variance value of a []T data input.
+It returns an error if the data is empty
+*/
+func VarianceOf[T Number](data []T) (float64, error) {
+	return Variance(Float64s(data))
+}
+
+/*
+StandardDeviationOf computes the standard deviation value of a []T data input.
+It returns an error if the data is empty
+*/
+func StandardDeviationOf[T Number](data []T) (float64, error) {
+	return StandardDeviation(Float64s(data))
+}
+
+/*
+MaxOf returns the maximum value of a []T data input.
+It returns an error if the data is empty
+*/
+func MaxOf[T Number](data []T) (T, error) {
+	if f, ok := any(data).([]float64); ok {
+		max, err := Max(f)
+		return T(max), err
+	}
+
+	if len(data) == 0 {
+		return 0, ErrEmptyData
+	}
+
+	max := data[0]
+	for _, d := range data {
+		if d != d {
+			// NaN values of floating point types propagate, as they do for float64
+			return d, nil
+		}
+		if d > max {
+			max = d
+		}
+	}
+	return max, nil
+}
+
+/*
+MinOf returns the minimum value of a []T data input.
+It returns an error if the data is empty
+*/
+func MinOf[T Number](data []T) (T, error) {
+	if f, ok := any(data).([]float64); ok {
+		min, err := Min(f)
+		return T(min), err
+	}
+
+	if len(data) == 0 {
+		return 0, ErrEmptyData
+	}
+
+	min := data[0]
+	for _, d := range data {
+		if d != d {
+			// NaN values of floating point types propagate, as they do for float64
+			return d, nil
+		}
+		if d < min {
+			min = d
+		}
+	}
+	return min, nil
+}
+
+/*
+RangeOf computes the difference between the maximum and the minimum value of a []T data input as a float64,
+so that it does not overflow for integers.
+It returns an error if the data is empty
+*/
+func RangeOf[T Number](data []T) (float64, error) {
+	max, err := MaxOf(data)
+	if err != nil {
+		return 0, err
+	}
+
+	min, err := MinOf(data)
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(max) - float64(min), nil
+}
+
+/*
+PercentileOf computes the percentile value of a []T data input given a percentage(%) value.
+It returns an error if the data is empty or the percentage is out of range (0 - 100%)
+*/
+func PercentileOf[T Number](data []T, p float64) (float64, error) {
+	return Percentile(Float64s(data), p)
+}
+
+/*
+PercentilesOf computes several percentile values of a []T data input given their percentage(%) values,
+sorting data only once.
+It returns an error if the data is empty or any percentage is out of range (0 - 100%)
+*/
+func PercentilesOf[T Number](data []T, ps []float64) ([]float64, error) {
+	return Percentiles(Float64s(data), ps)
+}
+
+/*
+QuantileOf computes the quantile value of a []T data input given a quantile index.
+- qs: desired quantile index
+- n: total amount of quantiles
+It returns an error if the data is empty or the quantile index is out of range (0 - n)
+*/
+func QuantileOf[T Number](data []T, qs float64, n uint) (float64, error) {
+	return Quantile(Float64s(data), qs, n)
+}
+
+/*
+IQROf computes the interquartile range of a []T data input.
+It returns an error if the data is empty
+*/
+func IQROf[T Number](data []T) (float64, error) {
+	return IQR(Float64s(data))
+}
+
+/*
+SkewnessOf computes the skewness value of a []T data input.
+It returns an error if the data is empty or the standard deviation is null
+*/
+func SkewnessOf[T Number](data []T) (float64, error) {
+	return Skewness(Float64s(data))
+}
+
+/*
+KurtosisOf computes the kurtosis value of a []T data input.
+It returns an error if the data is empty or the standard deviation is null
+*/
+func KurtosisOf[T Number](data []T) (float64, error) {
+	return Kurtosis(Float64s(data))
+}
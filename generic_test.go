@@ -0,0 +1,165 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+type celsius float32
+
+func TestGeneric(t *testing.T) {
+	floats := []float64{4, 8, 15, 16, 23, 42, 8}
+	ints := []int{4, 8, 15, 16, 23, 42, 8}
+	counters := []int64{4, 8, 15, 16, 23, 42, 8}
+	bytes := []uint8{4, 8, 15, 16, 23, 42, 8}
+	tensors := []float32{4, 8, 15, 16, 23, 42, 8}
+	temperatures := []celsius{4, 8, 15, 16, 23, 42, 8}
+
+	expected := map[string]float64{"sum": Sum(floats)}
+	for name, f := range map[string]func([]float64) (float64, error){
+		"mean": Mean, "median": Median, "mode": Mode, "variance": Variance, "std": StandardDeviation,
+		"max": Max, "min": Min, "range": Range, "iqr": IQR, "skewness": Skewness, "kurtosis": Kurtosis,
+		"percentile": func(d []float64) (float64, error) { return Percentile(d, 30) },
+		"quantile":   func(d []float64) (float64, error) { return Quantile(d, 2, 3) },
+	} {
+		v, err := f(floats)
+		if err != nil {
+			t.Fatalf("unexpected error received computing %v: %v", name, err)
+		}
+		expected[name] = v
+	}
+
+	results := map[string]map[string]float64{
+		"float64": genericResults(t, floats),
+		"int":     genericResults(t, ints),
+		"int64":   genericResults(t, counters),
+		"uint8":   genericResults(t, bytes),
+		"float32": genericResults(t, tensors),
+		"celsius": genericResults(t, temperatures),
+	}
+
+	for typ, res := range results {
+		for name, v := range res {
+			if math.Abs(v-expected[name]) > 1e-12 {
+				t.Errorf("expected %v of %v data: %v, got:%v", name, typ, expected[name], v)
+			}
+		}
+	}
+}
+
+// Returns the results of the generic functions by name, failing on errors
+func genericResults[T Number](t *testing.T, data []T) map[string]float64 {
+	t.Helper()
+	res := map[string]float64{"sum": SumOf(data)}
+	for name, f := range map[string]func([]T) (float64, error){
+		"mean": MeanOf[T], "median": MedianOf[T], "variance": VarianceOf[T], "std": StandardDeviationOf[T],
+		"range": RangeOf[T], "iqr": IQROf[T], "skewness": SkewnessOf[T], "kurtosis": KurtosisOf[T],
+		"percentile": func(d []T) (float64, error) { return PercentileOf(d, 30) },
+		"quantile":   func(d []T) (float64, error) { return QuantileOf(d, 2, 3) },
+	} {
+		v, err := f(data)
+		if err != nil {
+			t.Fatalf("unexpected error received computing %v: %v", name, err)
+		}
+		res[name] = v
+	}
+
+	for name, f := range map[string]func([]T) (T, error){"mode": ModeOf[T], "max": MaxOf[T], "min": MinOf[T]} {
+		v, err := f(data)
+		if err != nil {
+			t.Fatalf("unexpected error received computing %v: %v", name, err)
+		}
+		res[name] = float64(v)
+	}
+	return res
+}
+
+func TestGenericEmptyData(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"Mean", second(MeanOf([]int{}))},
+		{"Median", second(MedianOf([]uint16{}))},
+		{"Variance", second(VarianceOf([]float32{}))},
+		{"Max", second(MaxOf([]int32{}))},
+		{"Min", second(MinOf([]int8{}))},
+		{"Range", second(RangeOf([]uint{}))},
+		{"Percentile", second(PercentileOf([]int{}, 50))},
+		{"Percentiles", second(PercentilesOf([]int{}, []float64{50, 90}))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err != ErrEmptyData {
+				t.Errorf("expected error: %v, got:%v", ErrEmptyData, tt.err)
+			}
+		})
+	}
+
+	if sum := SumOf([]int{}); sum != 0 {
+		t.Errorf("expected sum: %v, got:%v", 0, sum)
+	}
+}
+
+func TestGenericNaN(t *testing.T) {
+	nan := float32(math.NaN())
+	tensors := []float32{3, nan, 1, 4, 5}
+	temperatures := []celsius{3, 1, 4, 5, celsius(nan)}
+
+	for name, f := range map[string]func() (float64, error){
+		"mean":       func() (float64, error) { return MeanOf(tensors) },
+		"median":     func() (float64, error) { return MedianOf(temperatures) },
+		"variance":   func() (float64, error) { return VarianceOf(tensors) },
+		"std":        func() (float64, error) { return StandardDeviationOf(temperatures) },
+		"range":      func() (float64, error) { return RangeOf(tensors) },
+		"percentile": func() (float64, error) { return PercentileOf(temperatures, 40) },
+		"quantile":   func() (float64, error) { return QuantileOf(tensors, 1, 3) },
+		"iqr":        func() (float64, error) { return IQROf(temperatures) },
+		"skewness":   func() (float64, error) { return SkewnessOf(tensors) },
+		"kurtosis":   func() (float64, error) { return KurtosisOf(temperatures) },
+		"sum":        func() (float64, error) { return SumOf(tensors), nil },
+		"mode": func() (float64, error) {
+			mode, err := ModeOf(tensors)
+			return float64(mode), err
+		},
+		"max": func() (float64, error) {
+			max, err := MaxOf(temperatures)
+			return float64(max), err
+		},
+		"min": func() (float64, error) {
+			min, err := MinOf(tensors)
+			return float64(min), err
+		},
+	} {
+		if v, err := f(); err != nil || !math.IsNaN(v) {
+			t.Errorf("expected propagated NaN %v, got:%v (error %v)", name, v, err)
+		}
+	}
+
+	percentiles, err := PercentilesOf(tensors, []float64{25, 75})
+	if err != nil || !math.IsNaN(percentiles[0]) || !math.IsNaN(percentiles[1]) {
+		t.Errorf("expected propagated NaN percentiles, got:%v (error %v)", percentiles, err)
+	}
+}
+
+func TestGenericFastPath(t *testing.T) {
+	data := []float64{1, 2, 3}
+	if f := Float64s(data); &f[0] != &data[0] {
+		t.Errorf("expected float64 data not to be copied")
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		MeanOf(data)
+		VarianceOf(data)
+		MaxOf(data)
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations for float64 data, got:%v", allocs)
+	}
+
+	// Integer ranges do not overflow
+	if r, _ := RangeOf([]int8{-128, 127}); r != 255 {
+		t.Errorf("expected range: %v, got:%v", 255, r)
+	}
+}
@@ -0,0 +1,340 @@
+package hypothesis
+
+import (
+	"math"
+
+	"github.com/jaumefe/stats"
+	"github.com/jaumefe/stats/distuv"
+	randvar "github.com/jaumefe/stats/rand_var"
+)
+
+/*
+ANOVARow holds a line of an analysis of variance table:
+  - Source: source of variation, such as a factor, an interaction or the residuals
+  - SS: sum of squares
+  - DoF: degrees of freedom
+  - MS: mean square, SS / DoF
+  - F: F statistic, MS over the residual mean square (effects only)
+  - PValue: p-value of the F statistic (effects only)
+*/
+type ANOVARow struct {
+	Source string
+	SS     float64
+	DoF    float64
+	MS     float64
+	F      float64
+	PValue float64
+}
+
+/*
+ANOVATable holds the outcome of an analysis of variance:
+  - Effects: one row per tested effect, in the order they enter the model
+  - Residuals: residual variation, whose F and PValue are not defined
+*/
+type ANOVATable struct {
+	Effects   []ANOVARow
+	Residuals ANOVARow
+}
+
+/*
+Factor returns the level of a factor a variable belongs to, for instance its category.
+It allows grouping variables by any of their metadata or a combination of them
+*/
+type Factor func(*randvar.AdvRandVar) string
+
+// ByCategory is the factor given by the category of the variables metadata
+func ByCategory(v *randvar.AdvRandVar) string {
+	return v.Category()
+}
+
+/*
+ANOVAOptions to set special features of the two-way analysis of variance:
+  - NoInteraction: fits an additive model without the interaction term,
+    needed when there is a single observation per combination of levels
+*/
+type ANOVAOptions struct {
+	NoInteraction bool
+}
+
+// Observations sharing a factor level
+type group struct {
+	level string
+	data  []float64
+	mean  float64
+}
+
+/*
+Gathers the data of the variables sharing the same level of a factor into groups,
+in order of appearance. It returns an error if there are no data
+*/
+func groupBy(vars []*randvar.AdvRandVar, factor Factor) ([]*group, error) {
+	groups := make([]*group, 0)
+	index := make(map[string]int)
+	for _, v := range vars {
+		if v == nil || v.RandVar == nil {
+			continue
+		}
+
+		level := factor(v)
+		i, ok := index[level]
+		if !ok {
+			i = len(groups)
+			index[level] = i
+			groups = append(groups, &group{level: level})
+		}
+		groups[i].data = append(groups[i].data, v.Data()...)
+	}
+
+	total := 0
+	for _, g := range groups {
+		total += len(g.data)
+		if len(g.data) > 0 {
+			g.mean, _ = stats.Mean(g.data)
+		}
+	}
+	if total == 0 {
+		return nil, stats.ErrEmptyData
+	}
+
+	return groups, nil
+}
+
+// Gathers the data of the variables sharing the same category into groups
+func groupByCategory(vars []*randvar.AdvRandVar) ([]*group, error) {
+	return groupBy(vars, ByCategory)
+}
+
+// Fills the mean squares, F statistics and p-values of a table given its sums of squares and degrees of freedom
+func completeTable(table *ANOVATable) (*ANOVATable, error) {
+	res := &table.Residuals
+	if res.DoF < 1 {
+		return nil, ErrNotEnoughData
+	}
+	res.MS = res.SS / res.DoF
+	if res.MS == 0 {
+		return nil, stats.ErrNullStdDeviation
+	}
+
+	for i := range table.Effects {
+		row := &table.Effects[i]
+		if row.DoF < 1 {
+			return nil, ErrNotEnoughData
+		}
+
+		row.MS = row.SS / row.DoF
+		row.F = row.MS / res.MS
+		dist, err := distuv.NewF(row.DoF, res.DoF)
+		if err != nil {
+			return nil, err
+		}
+		row.PValue = dist.Survival(row.F)
+	}
+
+	return table, nil
+}
+
+// Computes the one-way analysis of variance of some groups
+func oneWayANOVA(groups []*group) (*ANOVATable, error) {
+	if len(groups) < 2 {
+		return nil, ErrNotEnoughData
+	}
+
+	n := 0
+	grandSum := 0.0
+	for _, g := range groups {
+		if len(g.data) == 0 {
+			return nil, stats.ErrEmptyData
+		}
+		n += len(g.data)
+		grandSum += g.mean * float64(len(g.data))
+	}
+	grandMean := grandSum / float64(n)
+
+	between, within := 0.0, 0.0
+	for _, g := range groups {
+		d := g.mean - grandMean
+		between += float64(len(g.data)) * d * d
+		for _, v := range g.data {
+			within += (v - g.mean) * (v - g.mean)
+		}
+	}
+
+	k := len(groups)
+	return completeTable(&ANOVATable{
+		Effects:   []ANOVARow{{Source: "Between", SS: between, DoF: float64(k - 1)}},
+		Residuals: ANOVARow{Source: "Residuals", SS: within, DoF: float64(n - k)},
+	})
+}
+
+/*
+OneWayANOVA tests whether the means of several groups are equal, where groups are made of
+the variables sharing a category, in order of appearance.
+It returns an error if there are less than 2 groups, any of them is empty,
+there are not enough values to estimate the residual variance or it is null
+*/
+func OneWayANOVA(vars []*randvar.AdvRandVar) (*ANOVATable, error) {
+	groups, err := groupByCategory(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	return oneWayANOVA(groups)
+}
+
+/*
+Sequential least squares fit: each added column is orthogonalized against the previous ones,
+so the explained sum of squares of a term is the squared length of the projection of the
+current residuals onto its new directions
+*/
+type sequentialFit struct {
+	basis    [][]float64
+	residual []float64
+}
+
+// Adds the columns of a term to the fit and returns its sum of squares and degrees of freedom
+func (f *sequentialFit) add(columns [][]float64) (float64, int) {
+	ss := 0.0
+	rank := 0
+	for _, col := range columns {
+		q := append([]float64(nil), col...)
+		norm := math.Sqrt(dot(q, q))
+		if norm == 0 {
+			continue
+		}
+
+		// Orthogonalizing twice keeps the basis orthogonal up to rounding errors
+		for pass := 0; pass < 2; pass++ {
+			for _, b := range f.basis {
+				axpy(-dot(q, b), b, q)
+			}
+		}
+
+		qNorm := math.Sqrt(dot(q, q))
+		if qNorm <= 1e-10*norm {
+			continue
+		}
+		for i := range q {
+			q[i] /= qNorm
+		}
+
+		proj := dot(f.residual, q)
+		axpy(-proj, q, f.residual)
+		f.basis = append(f.basis, q)
+		ss += proj * proj
+		rank++
+	}
+
+	return ss, rank
+}
+
+// Returns the dot product of two vectors
+func dot(x, y []float64) float64 {
+	sum := 0.0
+	for i := range x {
+		sum += x[i] * y[i]
+	}
+	return sum
+}
+
+// Adds a·x to y
+func axpy(a float64, x, y []float64) {
+	for i := range x {
+		y[i] += a * x[i]
+	}
+}
+
+/*
+TwoWayANOVA tests the effects of two factors, and their interaction, on the mean of the variables.
+Sums of squares are sequential (type I): factor A, then factor B and finally the interaction,
+which matches the classical decomposition for balanced designs.
+It returns an error if there are no data, any factor has less than 2 levels,
+there are not enough values to estimate the residual variance or it is null
+*/
+func TwoWayANOVA(vars []*randvar.AdvRandVar, factorA, factorB Factor, opts *ANOVAOptions) (*ANOVATable, error) {
+	if opts == nil {
+		opts = &ANOVAOptions{}
+	}
+
+	// Each observation is described by the levels of both factors
+	var y []float64
+	var levelsA, levelsB []int
+	indexA, indexB := make(map[string]int), make(map[string]int)
+	for _, v := range vars {
+		if v == nil || v.RandVar == nil {
+			continue
+		}
+
+		a, ok := indexA[factorA(v)]
+		if !ok {
+			a = len(indexA)
+			indexA[factorA(v)] = a
+		}
+		b, ok := indexB[factorB(v)]
+		if !ok {
+			b = len(indexB)
+			indexB[factorB(v)] = b
+		}
+
+		for _, x := range v.Data() {
+			y = append(y, x)
+			levelsA = append(levelsA, a)
+			levelsB = append(levelsB, b)
+		}
+	}
+
+	n := len(y)
+	if n == 0 {
+		return nil, stats.ErrEmptyData
+	}
+
+	// Indicator columns of all levels but the first one
+	indicators := func(levels []int, count int) [][]float64 {
+		cols := make([][]float64, count-1)
+		for l := range cols {
+			cols[l] = make([]float64, n)
+			for i, level := range levels {
+				if level == l+1 {
+					cols[l][i] = 1
+				}
+			}
+		}
+		return cols
+	}
+	colsA := indicators(levelsA, len(indexA))
+	colsB := indicators(levelsB, len(indexB))
+
+	intercept := make([]float64, n)
+	for i := range intercept {
+		intercept[i] = 1
+	}
+	fit := &sequentialFit{residual: append([]float64(nil), y...)}
+	fit.add([][]float64{intercept})
+
+	ssA, dofA := fit.add(colsA)
+	ssB, dofB := fit.add(colsB)
+	table := &ANOVATable{Effects: []ANOVARow{
+		{Source: "A", SS: ssA, DoF: float64(dofA)},
+		{Source: "B", SS: ssB, DoF: float64(dofB)},
+	}}
+	rank := 1 + dofA + dofB
+
+	if !opts.NoInteraction {
+		colsAB := make([][]float64, 0, len(colsA)*len(colsB))
+		for _, ca := range colsA {
+			for _, cb := range colsB {
+				col := make([]float64, n)
+				for i := range col {
+					col[i] = ca[i] * cb[i]
+				}
+				colsAB = append(colsAB, col)
+			}
+		}
+
+		ssAB, dofAB := fit.add(colsAB)
+		table.Effects = append(table.Effects, ANOVARow{Source: "A:B", SS: ssAB, DoF: float64(dofAB)})
+		rank += dofAB
+	}
+
+	table.Residuals = ANOVARow{Source: "Residuals", SS: dot(fit.residual, fit.residual), DoF: float64(n - rank)}
+	return completeTable(table)
+}
@@ -0,0 +1,153 @@
+package hypothesis
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jaumefe/stats"
+	randvar "github.com/jaumefe/stats/rand_var"
+)
+
+// Returns a random variable with the given data and category
+func categorized(data []float64, category string) *randvar.AdvRandVar {
+	v := randvar.NewAdvRandVar(data)
+	v.DefineMeta("", "", "", "", category)
+	return v
+}
+
+// Dried weight of plants from R's PlantGrowth dataset: a control and two treatments
+var plantGrowth = []*randvar.AdvRandVar{
+	categorized([]float64{4.17, 5.58, 5.18, 6.11, 4.50}, "ctrl"),
+	categorized([]float64{4.81, 4.17, 4.41, 3.59, 5.87, 3.83, 6.03, 4.89, 4.32, 4.69}, "trt1"),
+	categorized([]float64{4.61, 5.17, 4.53, 5.33, 5.14}, "ctrl"),
+	categorized([]float64{6.31, 5.12, 5.54, 5.50, 5.37, 5.29, 4.92, 6.15, 5.80, 5.26}, "trt2"),
+}
+
+// Unbalanced two factor design whose category holds both levels separated by a slash
+var twoWayData = []*randvar.AdvRandVar{
+	categorized([]float64{4.2, 4.8, 5.1}, "a1/b1"),
+	categorized([]float64{5.9, 6.3}, "a1/b2"),
+	categorized([]float64{7.1, 6.8, 7.4, 7.0}, "a1/b3"),
+	categorized([]float64{3.9, 4.4}, "a2/b1"),
+	categorized([]float64{6.8, 7.2, 7.5}, "a2/b2"),
+	categorized([]float64{8.9, 9.3}, "a2/b3"),
+}
+
+// Factors given by the first and second parts of the category
+func firstLevel(v *randvar.AdvRandVar) string  { return v.Category()[:2] }
+func secondLevel(v *randvar.AdvRandVar) string { return v.Category()[3:] }
+
+func checkANOVATable(t *testing.T, got *ANOVATable, expected ANOVATable) {
+	t.Helper()
+
+	if len(got.Effects) != len(expected.Effects) {
+		t.Fatalf("expected effects: %v, got:%v", expected.Effects, got.Effects)
+	}
+
+	rows := append([]ANOVARow{expected.Residuals}, expected.Effects...)
+	gotRows := append([]ANOVARow{got.Residuals}, got.Effects...)
+	for i, row := range rows {
+		g := gotRows[i]
+		if g.Source != row.Source || g.DoF != row.DoF {
+			t.Errorf("expected row: %v, got:%v", row, g)
+		}
+		if !closeTo(g.SS, row.SS, 10) || !closeTo(g.MS, row.SS/row.DoF, 10) {
+			t.Errorf("expected sum of squares: %v, got:%v", row.SS, g.SS)
+		}
+		if !closeTo(g.F, row.F, 8) {
+			t.Errorf("expected F statistic for %v: %v, got:%v", row.Source, row.F, g.F)
+		}
+		if math.Abs(g.PValue-row.PValue) > 1e-9 {
+			t.Errorf("expected p-value for %v: %v, got:%v", row.Source, row.PValue, g.PValue)
+		}
+	}
+}
+
+func TestOneWayANOVA(t *testing.T) {
+	table, err := OneWayANOVA(plantGrowth)
+	if err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+
+	// F(2, 27) survival function has the closed form (1 + 2F/27)^(-27/2)
+	checkANOVATable(t, table, ANOVATable{
+		Effects: []ANOVARow{{
+			Source: "Between", SS: 3.76634, DoF: 2, F: 4.846087862380138, PValue: 0.015909958325622888,
+		}},
+		Residuals: ANOVARow{Source: "Residuals", SS: 10.49209, DoF: 27},
+	})
+}
+
+// Reference sums of squares were computed solving the normal equations of the nested models with exact fractions
+func TestTwoWayANOVA(t *testing.T) {
+	table, err := TwoWayANOVA(twoWayData, firstLevel, secondLevel, nil)
+	if err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+
+	checkANOVATable(t, table, ANOVATable{
+		Effects: []ANOVARow{
+			{Source: "A", SS: 2.4603571428571427, DoF: 1, F: 21.597868115790572, PValue: 0.0009117174595913328},
+			{Source: "B", SS: 30.434571428571427, DoF: 2, F: 133.58261051311527, PValue: 6.113731079172823e-08},
+			{Source: "A:B", SS: 4.243404761904762, DoF: 2, F: 18.62503918904797, PValue: 0.0004246071800446099},
+		},
+		Residuals: ANOVARow{Source: "Residuals", SS: 1.1391666666666667, DoF: 10},
+	})
+}
+
+func TestTwoWayANOVABalanced(t *testing.T) {
+	// With one observation per cell, the additive model matches the classical row and column decomposition
+	vars := []*randvar.AdvRandVar{
+		categorized([]float64{1}, "a1/b1"), categorized([]float64{3}, "a1/b2"), categorized([]float64{8}, "a1/b3"),
+		categorized([]float64{2}, "a2/b1"), categorized([]float64{6}, "a2/b2"), categorized([]float64{7}, "a2/b3"),
+	}
+
+	if _, err := TwoWayANOVA(vars, firstLevel, secondLevel, nil); err != ErrNotEnoughData {
+		t.Errorf("expected error: %v, got:%v", ErrNotEnoughData, err)
+	}
+
+	table, err := TwoWayANOVA(vars, firstLevel, secondLevel, &ANOVAOptions{NoInteraction: true})
+	if err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+
+	// Grand mean 4.5, row means 4 and 5, column means 1.5, 4.5 and 7.5
+	expected := []float64{3 * (0.25 + 0.25), 2 * (9 + 0 + 9)}
+	total := 0.0
+	for _, v := range []float64{1, 3, 8, 2, 6, 7} {
+		total += (v - 4.5) * (v - 4.5)
+	}
+	for i, row := range table.Effects {
+		if !closeTo(row.SS, expected[i], 10) {
+			t.Errorf("expected sum of squares for %v: %v, got:%v", row.Source, expected[i], row.SS)
+		}
+	}
+	if residual := total - expected[0] - expected[1]; !closeTo(table.Residuals.SS, residual, 10) || table.Residuals.DoF != 2 {
+		t.Errorf("expected residuals: %v with 2 degrees of freedom, got:%v", residual, table.Residuals)
+	}
+}
+
+func TestANOVAErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"No variables", second(OneWayANOVA(nil)), stats.ErrEmptyData},
+		{"Single group", second(OneWayANOVA(plantGrowth[:1])), ErrNotEnoughData},
+		{"Empty group", second(OneWayANOVA(append(plantGrowth, categorized(nil, "trt3")))), stats.ErrEmptyData},
+		{"Constant groups", second(OneWayANOVA([]*randvar.AdvRandVar{
+			categorized([]float64{1, 1}, "a"), categorized([]float64{2, 2}, "b"),
+		})), stats.ErrNullStdDeviation},
+		{"Single level factor", second(TwoWayANOVA(twoWayData, firstLevel, func(*randvar.AdvRandVar) string { return "" }, nil)), ErrNotEnoughData},
+		{"Non estimable interaction", second(TwoWayANOVA(twoWayData, firstLevel, ByCategory, nil)), ErrNotEnoughData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err != tt.want {
+				t.Errorf("expected error: %v, got:%v", tt.want, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package hypothesis
+
+import (
+	"math"
+	"sort"
+
+	"github.com/jaumefe/stats"
+	"github.com/jaumefe/stats/distuv"
+)
+
+/*
+ChiSquareOptions to set special features of the chi-square tests:
+  - DDoF: degrees of freedom lost by parameters estimated from the data (goodness-of-fit only)
+  - YatesCorrection: apply Yates' continuity correction (2x2 contingency tables only)
+*/
+type ChiSquareOptions struct {
+	DDoF            int
+	YatesCorrection bool
+}
+
+/*
+ChiSquareResult holds the outcome of a chi-square test:
+  - Statistic: chi-square statistic
+  - DoF: degrees of freedom
+  - PValue: probability of a statistic at least as large under the null hypothesis
+  - Expected: expected counts under the null hypothesis, a single row for goodness-of-fit tests
+  - Warning: ErrLowExpectedCount when some expected count is below 5, nil otherwise
+*/
+type ChiSquareResult struct {
+	Statistic float64
+	DoF       float64
+	PValue    float64
+	Expected  [][]float64
+	Warning   error
+}
+
+// Builds the result of a chi-square test given the statistic, the degrees of freedom and the expected counts
+func chiSquareResult(statistic float64, dof int, expected [][]float64) (*ChiSquareResult, error) {
+	if dof < 1 {
+		return nil, ErrNotEnoughData
+	}
+
+	dist, err := distuv.NewChiSquared(float64(dof))
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ChiSquareResult{
+		Statistic: statistic,
+		DoF:       float64(dof),
+		PValue:    dist.Survival(statistic),
+		Expected:  expected,
+	}
+
+	for _, row := range expected {
+		for _, e := range row {
+			if e < 5 {
+				res.Warning = ErrLowExpectedCount
+			}
+		}
+	}
+
+	return res, nil
+}
+
+/*
+ChiSquareGoodnessOfFit tests whether observed counts follow the expected proportions.
+Expected values are rescaled to the observed total, so both expected counts and
+probabilities are accepted; a nil expected slice means equally likely categories.
+Frequencies computed by stats.Frequency are turned into observed counts by FrequencyCounts.
+It returns an error if lengths differ, there are less than 2 categories, any observed count
+is negative, every observed count is 0 or any expected value is not positive
+*/
+func ChiSquareGoodnessOfFit(observed, expected []float64, opts *ChiSquareOptions) (*ChiSquareResult, error) {
+	if opts == nil {
+		opts = &ChiSquareOptions{}
+	}
+
+	k := len(observed)
+	if k == 0 {
+		return nil, stats.ErrEmptyData
+	}
+
+	if expected == nil {
+		expected = make([]float64, k)
+		for i := range expected {
+			expected[i] = 1
+		}
+	}
+
+	if len(expected) != k {
+		return nil, stats.ErrDifferentLength
+	}
+
+	for i := range observed {
+		if observed[i] < 0 {
+			return nil, ErrNegativeCount
+		}
+		if !(expected[i] > 0) {
+			return nil, ErrNullExpectedCount
+		}
+	}
+
+	total := stats.Sum(observed)
+	if total == 0 {
+		return nil, stats.ErrEmptyData
+	}
+
+	scale := total / stats.Sum(expected)
+	counts := make([]float64, k)
+	statistic := 0.0
+	for i := range observed {
+		counts[i] = expected[i] * scale
+		d := observed[i] - counts[i]
+		statistic += d * d / counts[i]
+	}
+
+	return chiSquareResult(statistic, k-1-opts.DDoF, [][]float64{counts})
+}
+
+/*
+FrequencyCounts turns the frequencies computed by stats.Frequency into observed counts,
+sorted by their values, which are returned in the same order so that the expected counts
+or probabilities of ChiSquareGoodnessOfFit can be given for each of them
+*/
+func FrequencyCounts(freq map[float64]int) (values, counts []float64) {
+	values = make([]float64, 0, len(freq))
+	for v := range freq {
+		values = append(values, v)
+	}
+	sort.Float64s(values)
+
+	counts = make([]float64, len(values))
+	for i, v := range values {
+		counts[i] = float64(freq[v])
+	}
+	return values, counts
+}
+
+/*
+ChiSquareIndependence tests whether rows and columns of an r×c contingency table are independent.
+Yates' continuity correction is applied to 2x2 tables when opts.YatesCorrection is set.
+It returns an error if the table is smaller than 2x2 or ragged, any count is negative
+or any row or column sums 0
+*/
+func ChiSquareIndependence(table [][]float64, opts *ChiSquareOptions) (*ChiSquareResult, error) {
+	if opts == nil {
+		opts = &ChiSquareOptions{}
+	}
+
+	r := len(table)
+	if r < 2 || len(table[0]) < 2 {
+		return nil, ErrInvalidTable
+	}
+
+	c := len(table[0])
+	rowSums := make([]float64, r)
+	colSums := make([]float64, c)
+	total := 0.0
+	for i, row := range table {
+		if len(row) != c {
+			return nil, ErrInvalidTable
+		}
+
+		for j, v := range row {
+			if v < 0 {
+				return nil, ErrNegativeCount
+			}
+			rowSums[i] += v
+			colSums[j] += v
+			total += v
+		}
+	}
+
+	yates := opts.YatesCorrection && r == 2 && c == 2
+	expected := make([][]float64, r)
+	statistic := 0.0
+	for i, row := range table {
+		expected[i] = make([]float64, c)
+		for j, v := range row {
+			e := rowSums[i] * colSums[j] / total
+			if !(e > 0) {
+				return nil, ErrNullExpectedCount
+			}
+			expected[i][j] = e
+
+			d := math.Abs(v - e)
+			if yates {
+				d -= math.Min(0.5, d)
+			}
+			statistic += d * d / e
+		}
+	}
+
+	return chiSquareResult(statistic, (r-1)*(c-1), expected)
+}
@@ -0,0 +1,167 @@
+package hypothesis
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/jaumefe/stats"
+)
+
+type chiSquareTest struct {
+	name     string
+	test     func() (*ChiSquareResult, error)
+	expected ChiSquareResult
+}
+
+// Chi-square survival functions have closed forms for 1 and 2 degrees of freedom
+var chiSquareTests = []chiSquareTest{
+	{
+		name: "Goodness-of-fit with probabilities",
+		test: func() (*ChiSquareResult, error) {
+			return ChiSquareGoodnessOfFit([]float64{20, 30, 50}, []float64{0.3, 0.3, 0.4}, nil)
+		},
+		expected: ChiSquareResult{
+			Statistic: 35.0 / 6, DoF: 2, PValue: math.Exp(-35.0 / 12),
+			Expected: [][]float64{{30, 30, 40}},
+		},
+	},
+	{
+		name: "Goodness-of-fit with counts",
+		test: func() (*ChiSquareResult, error) {
+			return ChiSquareGoodnessOfFit([]float64{20, 30, 50}, []float64{30, 30, 40}, nil)
+		},
+		expected: ChiSquareResult{
+			Statistic: 35.0 / 6, DoF: 2, PValue: math.Exp(-35.0 / 12),
+			Expected: [][]float64{{30, 30, 40}},
+		},
+	},
+	{
+		name: "Goodness-of-fit against uniform with estimated parameter",
+		test: func() (*ChiSquareResult, error) {
+			return ChiSquareGoodnessOfFit([]float64{8, 12, 10, 10}, nil, &ChiSquareOptions{DDoF: 1})
+		},
+		expected: ChiSquareResult{
+			Statistic: 0.8, DoF: 2, PValue: math.Exp(-0.4),
+			Expected: [][]float64{{10, 10, 10, 10}},
+		},
+	},
+	{
+		name: "Independence 2x2",
+		test: func() (*ChiSquareResult, error) {
+			return ChiSquareIndependence([][]float64{{10, 20}, {30, 40}}, nil)
+		},
+		expected: ChiSquareResult{
+			Statistic: 0.7936507936507936, DoF: 1, PValue: 0.37299848361348714,
+			Expected: [][]float64{{12, 18}, {28, 42}},
+		},
+	},
+	{
+		name: "Independence 2x2 with Yates correction",
+		test: func() (*ChiSquareResult, error) {
+			return ChiSquareIndependence([][]float64{{10, 20}, {30, 40}}, &ChiSquareOptions{YatesCorrection: true})
+		},
+		expected: ChiSquareResult{
+			Statistic: 0.4464285714285714, DoF: 1, PValue: 0.5040358664525048,
+			Expected: [][]float64{{12, 18}, {28, 42}},
+		},
+	},
+	{
+		name: "Independence 2x3 with low expected counts",
+		test: func() (*ChiSquareResult, error) {
+			return ChiSquareIndependence([][]float64{{12, 5, 3}, {7, 15, 1}}, nil)
+		},
+		expected: ChiSquareResult{
+			Statistic: 7.141247139588101, DoF: 2, PValue: 0.028138302013332773,
+			Expected: [][]float64{
+				{19.0 * 20 / 43, 20.0 * 20 / 43, 4.0 * 20 / 43},
+				{19.0 * 23 / 43, 20.0 * 23 / 43, 4.0 * 23 / 43},
+			},
+			Warning: ErrLowExpectedCount,
+		},
+	},
+}
+
+func TestChiSquare(t *testing.T) {
+	for _, tt := range chiSquareTests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := tt.test()
+			if err != nil {
+				t.Fatalf("unexpected error received: %v", err)
+			}
+
+			if math.Abs(res.Statistic-tt.expected.Statistic) > 1e-12 {
+				t.Errorf("expected statistic: %v, got:%v", tt.expected.Statistic, res.Statistic)
+			}
+
+			if res.DoF != tt.expected.DoF {
+				t.Errorf("expected degrees of freedom: %v, got:%v", tt.expected.DoF, res.DoF)
+			}
+
+			if math.Abs(res.PValue-tt.expected.PValue) > 1e-12 {
+				t.Errorf("expected p-value: %v, got:%v", tt.expected.PValue, res.PValue)
+			}
+
+			if len(res.Expected) != len(tt.expected.Expected) {
+				t.Fatalf("expected counts: %v, got:%v", tt.expected.Expected, res.Expected)
+			}
+			for i := range res.Expected {
+				if !stats.Equals(res.Expected[i], tt.expected.Expected[i], 1e-12) {
+					t.Errorf("expected counts: %v, got:%v", tt.expected.Expected, res.Expected)
+				}
+			}
+
+			if res.Warning != tt.expected.Warning {
+				t.Errorf("expected warning: %v, got:%v", tt.expected.Warning, res.Warning)
+			}
+		})
+	}
+}
+
+func TestFrequencyCounts(t *testing.T) {
+	// Rolls of a die, expected to be fair
+	rolls := []float64{6, 1, 3, 6, 2, 5, 6, 4, 1, 6, 3, 6, 5, 2, 6, 1}
+	freq, err := stats.Frequency(rolls, 0)
+	if err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+
+	values, counts := FrequencyCounts(freq)
+	if !reflect.DeepEqual(values, []float64{1, 2, 3, 4, 5, 6}) || !reflect.DeepEqual(counts, []float64{3, 2, 2, 1, 2, 6}) {
+		t.Errorf("expected values and counts: %v and %v, got:%v and %v", []float64{1, 2, 3, 4, 5, 6}, []float64{3, 2, 2, 1, 2, 6}, values, counts)
+	}
+
+	res, err := ChiSquareGoodnessOfFit(counts, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+	if math.Abs(res.Statistic-5.75) > 1e-12 || res.DoF != 5 {
+		t.Errorf("expected statistic %v with %v degrees of freedom, got:%v with %v", 5.75, 5, res.Statistic, res.DoF)
+	}
+}
+
+func TestChiSquareErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"Empty observed", second(ChiSquareGoodnessOfFit(nil, nil, nil)), stats.ErrEmptyData},
+		{"Different lengths", second(ChiSquareGoodnessOfFit([]float64{1, 2}, []float64{1}, nil)), stats.ErrDifferentLength},
+		{"Negative count", second(ChiSquareGoodnessOfFit([]float64{1, -2}, nil, nil)), ErrNegativeCount},
+		{"Null expected", second(ChiSquareGoodnessOfFit([]float64{1, 2}, []float64{1, 0}, nil)), ErrNullExpectedCount},
+		{"Single category", second(ChiSquareGoodnessOfFit([]float64{1}, nil, nil)), ErrNotEnoughData},
+		{"Null observed", second(ChiSquareGoodnessOfFit([]float64{0, 0}, nil, nil)), stats.ErrEmptyData},
+		{"Single row table", second(ChiSquareIndependence([][]float64{{1, 2}}, nil)), ErrInvalidTable},
+		{"Ragged table", second(ChiSquareIndependence([][]float64{{1, 2}, {3}}, nil)), ErrInvalidTable},
+		{"Empty column", second(ChiSquareIndependence([][]float64{{1, 0}, {3, 0}}, nil)), ErrNullExpectedCount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.err, tt.want) {
+				t.Errorf("expected error: %v, got:%v", tt.want, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,6 @@
+/*
+Package hypothesis provides statistical hypothesis tests. Samples can be given either
+as raw []float64 data or as *randvar.RandVar random variables, while analyses of variance
+group *randvar.AdvRandVar variables through their metadata category.
+*/
+package hypothesis
@@ -0,0 +1,15 @@
+package hypothesis
+
+import "errors"
+
+var (
+	ErrNotEnoughData          = errors.New("not enough data to compute the test")
+	ErrInvalidConfidenceLevel = errors.New("confidence level must be between 0 and 1")
+	ErrNegativeCount          = errors.New("observed counts must be greater or equal than 0")
+	ErrNullExpectedCount      = errors.New("expected counts must be greater than 0")
+	ErrInvalidTable           = errors.New("contingency table must have at least 2 rows and 2 columns of equal length")
+	ErrLowExpectedCount       = errors.New("some expected counts are below 5, the chi-square approximation may be inaccurate")
+	ErrTooMuchData            = errors.New("sample size exceeds the one supported by the test")
+	ErrOutOfSupport           = errors.New("data lies outside of the support of the distribution")
+	ErrUnknownFamily          = errors.New("unknown distribution family")
+)
@@ -0,0 +1,65 @@
+package hypothesis
+
+import (
+	"github.com/jaumefe/stats/distuv"
+	randvar "github.com/jaumefe/stats/rand_var"
+)
+
+// Sample is the set of types accepted as data by the tests: raw data or a random variable
+type Sample interface {
+	[]float64 | *randvar.RandVar
+}
+
+// Returns the data held by a sample
+func values[S Sample](s S) []float64 {
+	switch v := any(s).(type) {
+	case []float64:
+		return v
+	case *randvar.RandVar:
+		if v == nil {
+			return nil
+		}
+		return v.Data()
+	}
+	return nil
+}
+
+/*
+Alternative defines the alternative hypothesis of a test:
+  - TwoSided: the parameter differs from the hypothesized value
+  - Less: the parameter is less than the hypothesized value
+  - Greater: the parameter is greater than the hypothesized value
+*/
+type Alternative int
+
+const (
+	TwoSided Alternative = iota
+	Less
+	Greater
+)
+
+const defaultConfidenceLevel = 0.95
+
+// Returns the confidence level to use, defaulting to 95% when it is not set
+func confidenceLevel(level float64) (float64, error) {
+	if level == 0 {
+		return defaultConfidenceLevel, nil
+	}
+
+	if !(level > 0 && level < 1) {
+		return 0, ErrInvalidConfidenceLevel
+	}
+	return level, nil
+}
+
+// Returns the p-value of a statistic given the distribution under the null hypothesis
+func pValue(dist distuv.Continuous, statistic float64, alt Alternative) float64 {
+	switch alt {
+	case Less:
+		return dist.CDF(statistic)
+	case Greater:
+		return dist.Survival(statistic)
+	default:
+		return min(1, 2*min(dist.CDF(statistic), dist.Survival(statistic)))
+	}
+}
@@ -0,0 +1,397 @@
+package hypothesis
+
+import (
+	"math"
+	"slices"
+
+	"github.com/jaumefe/stats"
+	"github.com/jaumefe/stats/mathext"
+)
+
+// Sample sizes below which exact Kolmogorov-Smirnov p-values are computed by default
+const (
+	ksExactThreshold        = 100
+	ksTwoSampleExactProduct = 10000
+)
+
+/*
+Largest sample size for which exact two-sided one-sample p-values are computed even when requested,
+as their cost grows as n³, taking about a second at this size
+*/
+const ksMaxExact = 400
+
+// Two-sided one-sample p-values below which the sum of both one-sided tails is used
+const ksTailThreshold = 1e-8
+
+/*
+KSOptions to set special features of the Kolmogorov-Smirnov tests:
+  - Alternative: two-sided by default. Greater means the empirical CDF of the (first) sample lies above
+    the hypothesized (or second sample) CDF, using D+ = max(F - G); Less uses D- = max(G - F)
+  - Method: how the p-value is computed
+*/
+type KSOptions struct {
+	Alternative Alternative
+	Method      PValueMethod
+}
+
+/*
+GoodnessOfFitResult holds the outcome of a goodness-of-fit test:
+  - Statistic: D for Kolmogorov-Smirnov, A² for Anderson-Darling and W for Shapiro-Wilk
+  - PValue: p-value of the test
+  - Exact: whether the p-value comes from the exact null distribution
+*/
+type GoodnessOfFitResult struct {
+	Statistic float64
+	PValue    float64
+	Exact     bool
+}
+
+// Returns whether sorted data holds repeated values
+func hasTies(sorted []float64) bool {
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i] == sorted[i-1] {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the statistic matching the alternative out of D+ and D-
+func ksStatistic(dPlus, dMinus float64, alt Alternative) float64 {
+	switch alt {
+	case Less:
+		return dMinus
+	case Greater:
+		return dPlus
+	default:
+		return max(dPlus, dMinus)
+	}
+}
+
+/*
+KolmogorovSmirnovTest tests whether a sample comes from the continuous distribution with the given CDF,
+for instance the CDF method of any distuv.Continuous distribution.
+It returns an error if the sample is empty
+*/
+func KolmogorovSmirnovTest[S Sample](sample S, cdf func(float64) float64, opts *KSOptions) (*GoodnessOfFitResult, error) {
+	if opts == nil {
+		opts = &KSOptions{}
+	}
+
+	data := slices.Clone(values(sample))
+	n := len(data)
+	if n == 0 {
+		return nil, stats.ErrEmptyData
+	}
+	slices.Sort(data)
+
+	fn := float64(n)
+	dPlus, dMinus := 0.0, 0.0
+	for i, x := range data {
+		f := cdf(x)
+		dPlus = max(dPlus, float64(i+1)/fn-f)
+		dMinus = max(dMinus, f-float64(i)/fn)
+	}
+
+	d := ksStatistic(dPlus, dMinus, opts.Alternative)
+	res := &GoodnessOfFitResult{Statistic: d}
+
+	affordable := opts.Alternative != TwoSided || n <= ksMaxExact
+	if affordable && useExact(opts.Method, n < ksExactThreshold, hasTies(data)) {
+		res.Exact = true
+		if opts.Alternative == TwoSided {
+			res.PValue = kolmogorovExactSurvival(n, d)
+		} else {
+			res.PValue = smirnovSurvival(n, d)
+		}
+	} else {
+		if opts.Alternative == TwoSided {
+			res.PValue = kolmogorovSurvival(math.Sqrt(fn) * d)
+		} else {
+			res.PValue = math.Exp(-2 * fn * d * d)
+		}
+	}
+
+	res.PValue = min(1, max(0, res.PValue))
+	return res, nil
+}
+
+/*
+KolmogorovSmirnovTwoSampleTest tests whether two independent samples come from the same continuous distribution.
+It returns an error if any of the samples is empty
+*/
+func KolmogorovSmirnovTwoSampleTest[S Sample](x, y S, opts *KSOptions) (*GoodnessOfFitResult, error) {
+	if opts == nil {
+		opts = &KSOptions{}
+	}
+
+	dataX, dataY := slices.Clone(values(x)), slices.Clone(values(y))
+	m, n := len(dataX), len(dataY)
+	if m == 0 || n == 0 {
+		return nil, stats.ErrEmptyData
+	}
+	slices.Sort(dataX)
+	slices.Sort(dataY)
+
+	// Walks through the distinct values of the combined sample comparing both empirical CDFs
+	dPlus, dMinus := 0.0, 0.0
+	for i, j := 0, 0; i < m || j < n; {
+		v := math.Inf(1)
+		if i < m {
+			v = dataX[i]
+		}
+		if j < n && dataY[j] < v {
+			v = dataY[j]
+		}
+
+		for i < m && dataX[i] == v {
+			i++
+		}
+		for j < n && dataY[j] == v {
+			j++
+		}
+
+		diff := float64(i)/float64(m) - float64(j)/float64(n)
+		dPlus = max(dPlus, diff)
+		dMinus = max(dMinus, -diff)
+	}
+
+	d := ksStatistic(dPlus, dMinus, opts.Alternative)
+	res := &GoodnessOfFitResult{Statistic: d}
+
+	combined := append(slices.Clone(dataX), dataY...)
+	slices.Sort(combined)
+	if useExact(opts.Method, m*n < ksTwoSampleExactProduct, hasTies(combined)) {
+		res.Exact = true
+		res.PValue = 1 - smirnovCDF(m, n, d, opts.Alternative)
+	} else {
+		en := float64(m*n) / float64(m+n)
+		if opts.Alternative == TwoSided {
+			res.PValue = kolmogorovSurvival(math.Sqrt(en) * d)
+		} else {
+			res.PValue = math.Exp(-2 * en * d * d)
+		}
+	}
+
+	res.PValue = min(1, max(0, res.PValue))
+	return res, nil
+}
+
+/*
+Returns P(K > x) for the limiting Kolmogorov distribution, using the faster converging
+of its two series representations
+*/
+func kolmogorovSurvival(x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+
+	if x < 1 {
+		// P(K <= x) = √(2π)/x Σ exp(-(2k-1)²π²/(8x²))
+		sum := 0.0
+		for k := 1; k <= 20; k++ {
+			j := float64(2*k - 1)
+			sum += math.Exp(-j * j * math.Pi * math.Pi / (8 * x * x))
+		}
+		return 1 - math.Sqrt(2*math.Pi)/x*sum
+	}
+
+	// P(K > x) = 2 Σ (-1)^(k-1) exp(-2k²x²)
+	sum := 0.0
+	sign := 1.0
+	for k := 1; k <= 100; k++ {
+		term := math.Exp(-2 * float64(k*k) * x * x)
+		sum += sign * term
+		if term < 1e-17 {
+			break
+		}
+		sign = -sign
+	}
+	return 2 * sum
+}
+
+/*
+Returns P(D >= d) for the two-sided one-sample statistic of a sample of size n. Both one-sided statistics
+can not reach d at once when d >= 0.5, and hardly do when their tails are small, so the sum of the tails
+is used then instead of the complement of kolmogorovCDF, which loses every digit below 1e-16
+*/
+func kolmogorovExactSurvival(n int, d float64) float64 {
+	tails := 2 * smirnovSurvival(n, d)
+	if d >= 0.5 || tails < ksTailThreshold {
+		return tails
+	}
+	return 1 - kolmogorovCDF(n, d)
+}
+
+/*
+Returns P(D < d) for the two-sided one-sample statistic of a sample of size n,
+following Marsaglia, Tsang and Wang (2003)
+*/
+func kolmogorovCDF(n int, d float64) float64 {
+	if d <= 0 {
+		return 0
+	}
+	if d >= 1 {
+		return 1
+	}
+
+	fn := float64(n)
+	k := int(fn*d) + 1
+	m := 2*k - 1
+	h := float64(k) - fn*d
+
+	mat := make([]float64, m*m)
+	for i := 0; i < m; i++ {
+		for j := 0; j < m; j++ {
+			if i-j+1 >= 0 {
+				mat[i*m+j] = 1
+			}
+		}
+	}
+	for i := 0; i < m; i++ {
+		mat[i*m] -= math.Pow(h, float64(i+1))
+		mat[(m-1)*m+i] -= math.Pow(h, float64(m-i))
+	}
+	if 2*h-1 > 0 {
+		mat[(m-1)*m] += math.Pow(2*h-1, float64(m))
+	}
+	for i := 0; i < m; i++ {
+		for j := 0; j < m; j++ {
+			for g := 2; g <= i-j+1; g++ {
+				mat[i*m+j] /= float64(g)
+			}
+		}
+	}
+
+	q, exp := matrixPower(mat, 0, m, n)
+	s := q[(k-1)*m+k-1]
+	for i := 1; i <= n; i++ {
+		s *= float64(i) / fn
+		if s < 1e-140 {
+			s *= 1e140
+			exp -= 140
+		}
+	}
+
+	return s * math.Pow(10, float64(exp))
+}
+
+// Returns the product of two m×m matrices
+func matrixMultiply(a, b []float64, m int) []float64 {
+	c := make([]float64, m*m)
+	for i := 0; i < m; i++ {
+		for k := 0; k < m; k++ {
+			aik := a[i*m+k]
+			if aik == 0 {
+				continue
+			}
+			for j := 0; j < m; j++ {
+				c[i*m+j] += aik * b[k*m+j]
+			}
+		}
+	}
+	return c
+}
+
+/*
+Returns the n-th power of an m×m matrix a scaled by 10^exp, as a matrix and its own decimal exponent.
+Results are rescaled as they grow to avoid overflows
+*/
+func matrixPower(a []float64, exp, m, n int) ([]float64, int) {
+	if n == 1 {
+		return a, exp
+	}
+
+	v, ev := matrixPower(a, exp, m, n/2)
+	v = matrixMultiply(v, v, m)
+	ev *= 2
+	if n%2 == 1 {
+		v = matrixMultiply(a, v, m)
+		ev += exp
+	}
+
+	if v[(m/2)*m+m/2] > 1e140 {
+		for i := range v {
+			v[i] *= 1e-140
+		}
+		ev += 140
+	}
+
+	return v, ev
+}
+
+/*
+Returns P(D+ >= d) for the one-sided one-sample statistic of a sample of size n,
+following Birnbaum and Tingey (1951)
+*/
+func smirnovSurvival(n int, d float64) float64 {
+	if d <= 0 {
+		return 1
+	}
+	if d >= 1 {
+		return 0
+	}
+
+	fn := float64(n)
+	sum := 0.0
+	for j := 0; j <= int(math.Floor(fn*(1-d))); j++ {
+		fj := float64(j)
+		term := mathext.Lgamma(fn+1) - mathext.Lgamma(fj+1) - mathext.Lgamma(fn-fj+1) +
+			(fn-fj)*math.Log(1-d-fj/fn) + (fj-1)*math.Log(d+fj/fn)
+		sum += math.Exp(term)
+	}
+
+	return d * sum
+}
+
+/*
+Returns P(D < d) for the two-sample statistic of samples of sizes m and n, counting the
+lattice paths through which the difference between both empirical CDFs stays below d
+*/
+func smirnovCDF(m, n int, d float64, alt Alternative) float64 {
+	// Differences are multiples of 1/mn, so they are compared as integers
+	k := int(math.Round(d * float64(m*n)))
+	if k <= 0 {
+		return 0
+	}
+
+	inside := func(i, j int) bool {
+		diff := i*n - j*m
+		switch alt {
+		case Less:
+			return -diff < k
+		case Greater:
+			return diff < k
+		default:
+			return diff < k && -diff < k
+		}
+	}
+
+	// u[j] holds the number of valid paths reaching (i, j) divided by C(i+n, i)
+	u := make([]float64, n+1)
+	for j := range u {
+		if inside(0, j) {
+			u[j] = 1
+		} else {
+			break
+		}
+	}
+
+	for i := 1; i <= m; i++ {
+		w := float64(i) / float64(i+n)
+		if inside(i, 0) {
+			u[0] *= w
+		} else {
+			u[0] = 0
+		}
+		for j := 1; j <= n; j++ {
+			if inside(i, j) {
+				u[j] = w*u[j] + u[j-1]
+			} else {
+				u[j] = 0
+			}
+		}
+	}
+
+	return u[n]
+}
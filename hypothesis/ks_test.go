@@ -0,0 +1,175 @@
+package hypothesis
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jaumefe/stats"
+	"github.com/jaumefe/stats/distuv"
+	randvar "github.com/jaumefe/stats/rand_var"
+)
+
+// Uniform CDF on [0, 1]
+func uniformCDF(x float64) float64 {
+	return math.Min(1, math.Max(0, x))
+}
+
+type goodnessOfFitTest struct {
+	name     string
+	test     func() (*GoodnessOfFitResult, error)
+	expected GoodnessOfFitResult
+}
+
+var (
+	ksX = []float64{0.61, 0.29, 0.06, 0.59, -1.73, -0.74, 0.51, -0.56, 0.39}
+	ksY = []float64{-0.08, 1.31, 2.12, 1.45, 0.83, 1.38, -0.03}
+)
+
+// One-sample exact p-values were checked through simulation and two-sample ones by enumerating all the arrangements of both samples
+var ksTests = []goodnessOfFitTest{
+	{
+		name: "One sample exact",
+		test: func() (*GoodnessOfFitResult, error) {
+			return KolmogorovSmirnovTest([]float64{0.1, 0.2, 0.3, 0.4, 0.5}, uniformCDF, nil)
+		},
+		expected: GoodnessOfFitResult{Statistic: 0.5, PValue: 0.112, Exact: true},
+	},
+	{
+		name: "One sample one-sided exact",
+		test: func() (*GoodnessOfFitResult, error) {
+			return KolmogorovSmirnovTest([]float64{0.5}, uniformCDF, &KSOptions{Alternative: Less})
+		},
+		expected: GoodnessOfFitResult{Statistic: 0.5, PValue: 0.5, Exact: true},
+	},
+	{
+		name: "One sample asymptotic",
+		test: func() (*GoodnessOfFitResult, error) {
+			normal, _ := distuv.NewNormal(0, 1)
+			return KolmogorovSmirnovTest(randvar.NewRandVar(ksX), normal.CDF, &KSOptions{Method: AsymptoticPValue})
+		},
+		expected: GoodnessOfFitResult{Statistic: 0.2709309, PValue: 0.5235},
+	},
+	{
+		name: "Two sample exact",
+		test: func() (*GoodnessOfFitResult, error) {
+			return KolmogorovSmirnovTwoSampleTest(ksX, ksY, nil)
+		},
+		expected: GoodnessOfFitResult{Statistic: 5.0 / 7, PValue: 0.02097902097902098, Exact: true},
+	},
+	{
+		name: "Two sample one-sided exact",
+		test: func() (*GoodnessOfFitResult, error) {
+			return KolmogorovSmirnovTwoSampleTest(ksX, ksY, &KSOptions{Alternative: Greater})
+		},
+		expected: GoodnessOfFitResult{Statistic: 5.0 / 7, PValue: 0.01048951048951049, Exact: true},
+	},
+	{
+		name: "Two sample with ties",
+		test: func() (*GoodnessOfFitResult, error) {
+			return KolmogorovSmirnovTwoSampleTest([]float64{1, 2, 2, 3}, []float64{2, 3, 4, 5}, nil)
+		},
+		expected: GoodnessOfFitResult{Statistic: 0.5, PValue: 0.6994},
+	},
+}
+
+func TestKolmogorovSmirnov(t *testing.T) {
+	for _, tt := range ksTests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := tt.test()
+			if err != nil {
+				t.Fatalf("unexpected error received: %v", err)
+			}
+
+			if !closeTo(res.Statistic, tt.expected.Statistic, 6) {
+				t.Errorf("expected statistic: %v, got:%v", tt.expected.Statistic, res.Statistic)
+			}
+
+			if math.Abs(res.PValue-tt.expected.PValue) > 1e-4 {
+				t.Errorf("expected p-value: %v, got:%v", tt.expected.PValue, res.PValue)
+			}
+
+			if res.Exact != tt.expected.Exact {
+				t.Errorf("expected exact: %v, got:%v", tt.expected.Exact, res.Exact)
+			}
+		})
+	}
+}
+
+// Critical values at a 5% significance level from Miller (1956) and the limiting distribution
+func TestKolmogorovCriticalValues(t *testing.T) {
+	tests := []struct {
+		name string
+		got  float64
+	}{
+		{"n = 10", 1 - kolmogorovCDF(10, 0.40925)},
+		{"n = 20", 1 - kolmogorovCDF(20, 0.29408)},
+		{"Limiting distribution", kolmogorovSurvival(1.3581)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if math.Abs(tt.got-0.05) > 1e-4 {
+				t.Errorf("expected p-value: %v, got:%v", 0.05, tt.got)
+			}
+		})
+	}
+}
+
+func TestSmirnovSurvival(t *testing.T) {
+	// For n = 2 and d <= 1/2, D+ < d when both values exceed 1/2 - d and one of them exceeds 1 - d
+	d := 0.3
+	expected := 1 - ((0.5+d)*(0.5+d) - 0.25)
+	if got := smirnovSurvival(2, d); math.Abs(got-expected) > 1e-12 {
+		t.Errorf("expected p-value: %v, got:%v", expected, got)
+	}
+}
+
+func TestKolmogorovSmirnovTails(t *testing.T) {
+	// Values far in the upper tail give D = 0.98 through D-, whose probability is 0.02^40 for each tail
+	data := make([]float64, 40)
+	for i := range data {
+		data[i] = 0.98 + 0.0005*float64(i)
+	}
+
+	res, err := KolmogorovSmirnovTest(data, uniformCDF, &KSOptions{Method: ExactPValue})
+	expected := 2 * math.Pow(0.02, 40)
+	if err != nil || !res.Exact || math.Abs(res.PValue-expected) > 1e-9*expected {
+		t.Errorf("expected exact p-value: %v, got:%v (exact %v, error %v)", expected, res.PValue, res.Exact, err)
+	}
+
+	// Both one-sided tails are small enough to add them up before d reaches 0.5
+	d := 0.35
+	if got, cdf := kolmogorovExactSurvival(100, d), kolmogorovCDF(100, d); got >= ksTailThreshold || math.Abs(got-(1-cdf)) > 1e-4*got {
+		t.Errorf("expected p-value: %v, got:%v", 1-cdf, got)
+	}
+
+	// Samples too large for the exact distribution fall back to the limiting one
+	large := make([]float64, 1000)
+	for i := range large {
+		large[i] = math.Pow(float64(i)/1000, 1.1)
+	}
+	res, err = KolmogorovSmirnovTest(large, uniformCDF, &KSOptions{Method: ExactPValue})
+	asymptotic, _ := KolmogorovSmirnovTest(large, uniformCDF, &KSOptions{Method: AsymptoticPValue})
+	if err != nil || res.Exact || res.PValue != asymptotic.PValue {
+		t.Errorf("expected asymptotic p-value: %v, got:%v (exact %v, error %v)", asymptotic.PValue, res.PValue, res.Exact, err)
+	}
+}
+
+func TestKolmogorovSmirnovErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"Empty sample", second(KolmogorovSmirnovTest([]float64{}, uniformCDF, nil)), stats.ErrEmptyData},
+		{"Empty second sample", second(KolmogorovSmirnovTwoSampleTest(ksX, nil, nil)), stats.ErrEmptyData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err != tt.want {
+				t.Errorf("expected error: %v, got:%v", tt.want, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,396 @@
+package hypothesis
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/jaumefe/stats"
+	"github.com/jaumefe/stats/distuv"
+)
+
+// Sample sizes below which exact p-values are computed by default
+const exactThreshold = 50
+
+/*
+Largest sizes for which exact p-values are computed even when requested, as the cost of the exact
+distributions grows as (n1·n2)² for Mann-Whitney and as n³ for Wilcoxon, taking about a second at these sizes
+*/
+const (
+	maxExactMannWhitney = 10000
+	maxExactSignedRank  = 1000
+)
+
+/*
+PValueMethod defines how p-values of rank tests are computed:
+  - AutoPValue: exact for small samples without ties, normal approximation otherwise
+  - ExactPValue: exact whenever there are no ties, as long as the exact distribution is affordable:
+    up to 10000 pairs of values (n1·n2) for Mann-Whitney, 1000 differences for Wilcoxon and 400 values
+    for the two-sided one-sample Kolmogorov-Smirnov test. Larger samples fall back to the asymptotic p-values
+  - AsymptoticPValue: normal approximation
+*/
+type PValueMethod int
+
+const (
+	AutoPValue PValueMethod = iota
+	ExactPValue
+	AsymptoticPValue
+)
+
+/*
+RankTestOptions to set special features of the rank tests:
+  - Alternative: alternative hypothesis, two-sided by default
+  - Mu: hypothesized location shift (Mann-Whitney and Wilcoxon only)
+  - Method: how the p-value is computed
+  - NoCorrection: disables the continuity correction of the normal approximation
+*/
+type RankTestOptions struct {
+	Alternative  Alternative
+	Mu           float64
+	Method       PValueMethod
+	NoCorrection bool
+}
+
+/*
+RankTestResult holds the outcome of a rank test:
+  - Statistic: U for Mann-Whitney, V for Wilcoxon and H for Kruskal-Wallis
+  - PValue: p-value for the chosen alternative
+  - Exact: whether the p-value comes from the exact null distribution
+  - DoF: degrees of freedom of the chi-square approximation (Kruskal-Wallis only)
+*/
+type RankTestResult struct {
+	Statistic float64
+	PValue    float64
+	Exact     bool
+	DoF       float64
+}
+
+/*
+Returns the ranks (starting at 1) of data, giving tied values the average of their ranks,
+along with the tie correction term Σ(t³ - t), where t is the size of each group of ties
+*/
+func rank(data []float64) ([]float64, float64) {
+	n := len(data)
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	slices.SortStableFunc(idx, func(a, b int) int {
+		return cmp.Compare(data[a], data[b])
+	})
+
+	ranks := make([]float64, n)
+	ties := 0.0
+	for i := 0; i < n; {
+		j := i
+		for j+1 < n && data[idx[j+1]] == data[idx[i]] {
+			j++
+		}
+
+		r := float64(i+j)/2 + 1
+		for k := i; k <= j; k++ {
+			ranks[idx[k]] = r
+		}
+
+		t := float64(j - i + 1)
+		ties += t*t*t - t
+		i = j + 1
+	}
+
+	return ranks, ties
+}
+
+// Returns whether the exact null distribution has to be used, given whether the sample is small and has ties
+func useExact(method PValueMethod, small, tied bool) bool {
+	if tied {
+		return false
+	}
+
+	switch method {
+	case ExactPValue:
+		return true
+	case AsymptoticPValue:
+		return false
+	default:
+		return small
+	}
+}
+
+/*
+Returns the p-value of a statistic given its exact null distribution,
+where dist[s] is the probability of the statistic being s
+*/
+func exactPValue(dist []float64, statistic float64, alt Alternative) float64 {
+	s := int(math.Round(statistic))
+	lower, upper := 0.0, 0.0
+	for i, p := range dist {
+		if i <= s {
+			lower += p
+		}
+		if i >= s {
+			upper += p
+		}
+	}
+
+	switch alt {
+	case Less:
+		return min(1, lower)
+	case Greater:
+		return min(1, upper)
+	default:
+		return min(1, 2*min(lower, upper))
+	}
+}
+
+// Returns the p-value of a statistic through a normal approximation, with an optional continuity correction
+func normalPValue(statistic, mean, variance float64, alt Alternative, correct bool) (float64, error) {
+	if !(variance > 0) {
+		return 0, ErrNotEnoughData
+	}
+
+	d := statistic - mean
+	if correct {
+		switch alt {
+		case Less:
+			d += 0.5
+		case Greater:
+			d -= 0.5
+		default:
+			d -= math.Copysign(math.Min(0.5, math.Abs(d)), d)
+		}
+	}
+
+	normal, _ := distuv.NewNormal(0, 1)
+	return pValue(normal, d/math.Sqrt(variance), alt), nil
+}
+
+/*
+Returns the exact null distribution of the Mann-Whitney U statistic for samples of sizes n1 and n2.
+The largest of the combined values either belongs to the first sample, adding n2 to U, or to the second one
+*/
+func mannWhitneyDistribution(n1, n2 int) []float64 {
+	// The distribution is the same for swapped sizes, and tables grow with the square of the second one
+	if n2 > n1 {
+		n1, n2 = n2, n1
+	}
+
+	// Tables of consecutive sizes of the first sample are allocated once with their largest shape
+	prev, cur := make([][]float64, n2+1), make([][]float64, n2+1)
+	for n := range prev {
+		prev[n] = make([]float64, 1, n1*n+1)
+		cur[n] = make([]float64, 1, n1*n+1)
+		prev[n][0] = 1
+	}
+	cur[0][0] = 1
+
+	for m := 1; m <= n1; m++ {
+		for n := 1; n <= n2; n++ {
+			dist := cur[n][:m*n+1]
+			clear(dist)
+			px := float64(m) / float64(m+n)
+			for u, p := range prev[n] {
+				dist[u+n] += px * p
+			}
+			for u, p := range cur[n-1] {
+				dist[u] += (1 - px) * p
+			}
+			cur[n] = dist
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[n2]
+}
+
+/*
+MannWhitneyTest performs the Mann-Whitney U test (Wilcoxon rank-sum test) on two independent samples,
+testing whether values of x - opts.Mu tend to be larger or smaller than values of y.
+It returns an error if any of the samples is empty
+*/
+func MannWhitneyTest[S Sample](x, y S, opts *RankTestOptions) (*RankTestResult, error) {
+	if opts == nil {
+		opts = &RankTestOptions{}
+	}
+
+	dataX, dataY := values(x), values(y)
+	n1, n2 := len(dataX), len(dataY)
+	if n1 == 0 || n2 == 0 {
+		return nil, stats.ErrEmptyData
+	}
+
+	combined := make([]float64, 0, n1+n2)
+	for _, v := range dataX {
+		combined = append(combined, v-opts.Mu)
+	}
+	combined = append(combined, dataY...)
+
+	ranks, ties := rank(combined)
+	u := stats.Sum(ranks[:n1]) - float64(n1*(n1+1))/2
+	res := &RankTestResult{Statistic: u}
+
+	if n1*n2 <= maxExactMannWhitney && useExact(opts.Method, max(n1, n2) < exactThreshold, ties != 0) {
+		res.Exact = true
+		res.PValue = exactPValue(mannWhitneyDistribution(n1, n2), u, opts.Alternative)
+		return res, nil
+	}
+
+	n := float64(n1 + n2)
+	variance := float64(n1*n2) / 12 * ((n + 1) - ties/(n*(n-1)))
+	p, err := normalPValue(u, float64(n1*n2)/2, variance, opts.Alternative, !opts.NoCorrection)
+	if err != nil {
+		return nil, err
+	}
+
+	res.PValue = p
+	return res, nil
+}
+
+/*
+Returns the exact null distribution of the Wilcoxon signed-rank statistic for n non null differences:
+each rank is added to V with probability 1/2
+*/
+func signedRankDistribution(n int) []float64 {
+	dist := make([]float64, n*(n+1)/2+1)
+	dist[0] = 1
+	total := 0
+	for i := 1; i <= n; i++ {
+		total += i
+		for v := total; v >= i; v-- {
+			dist[v] = 0.5*dist[v] + 0.5*dist[v-i]
+		}
+		for v := i - 1; v >= 0; v-- {
+			dist[v] *= 0.5
+		}
+	}
+
+	return dist
+}
+
+/*
+WilcoxonSignedRankTest performs the Wilcoxon signed-rank test on a sample,
+testing whether it is symmetric around opts.Mu. Null differences are discarded.
+It returns an error if there are no non null differences
+*/
+func WilcoxonSignedRankTest[S Sample](sample S, opts *RankTestOptions) (*RankTestResult, error) {
+	if opts == nil {
+		opts = &RankTestOptions{}
+	}
+
+	data := values(sample)
+	if len(data) == 0 {
+		return nil, stats.ErrEmptyData
+	}
+
+	diff := make([]float64, 0, len(data))
+	zeros := 0
+	for _, v := range data {
+		if d := v - opts.Mu; d != 0 {
+			diff = append(diff, d)
+		} else {
+			zeros++
+		}
+	}
+
+	n := len(diff)
+	if n == 0 {
+		return nil, ErrNotEnoughData
+	}
+
+	abs := make([]float64, n)
+	for i, d := range diff {
+		abs[i] = math.Abs(d)
+	}
+
+	ranks, ties := rank(abs)
+	v := 0.0
+	for i, d := range diff {
+		if d > 0 {
+			v += ranks[i]
+		}
+	}
+	res := &RankTestResult{Statistic: v}
+
+	if zeros == 0 && n <= maxExactSignedRank && useExact(opts.Method, n < exactThreshold, ties != 0) {
+		res.Exact = true
+		res.PValue = exactPValue(signedRankDistribution(n), v, opts.Alternative)
+		return res, nil
+	}
+
+	fn := float64(n)
+	variance := fn*(fn+1)*(2*fn+1)/24 - ties/48
+	p, err := normalPValue(v, fn*(fn+1)/4, variance, opts.Alternative, !opts.NoCorrection)
+	if err != nil {
+		return nil, err
+	}
+
+	res.PValue = p
+	return res, nil
+}
+
+/*
+PairedWilcoxonTest performs the Wilcoxon signed-rank test on the differences between paired samples (x - y).
+It returns an error if samples have different lengths or all differences equal opts.Mu
+*/
+func PairedWilcoxonTest[S Sample](x, y S, opts *RankTestOptions) (*RankTestResult, error) {
+	dataX, dataY := values(x), values(y)
+	if len(dataX) != len(dataY) {
+		return nil, stats.ErrDifferentLength
+	}
+
+	diff := make([]float64, len(dataX))
+	for i := range dataX {
+		diff[i] = dataX[i] - dataY[i]
+	}
+
+	return WilcoxonSignedRankTest(diff, opts)
+}
+
+/*
+KruskalWallisTest tests whether several independent samples come from the same distribution.
+The statistic is corrected for ties and its p-value approximated by a chi-square distribution.
+It returns an error if there are less than 2 groups, any of them is empty or all values are tied
+*/
+func KruskalWallisTest[S Sample](groups ...S) (*RankTestResult, error) {
+	k := len(groups)
+	if k < 2 {
+		return nil, ErrNotEnoughData
+	}
+
+	sizes := make([]int, k)
+	combined := make([]float64, 0)
+	for i, g := range groups {
+		data := values(g)
+		if len(data) == 0 {
+			return nil, stats.ErrEmptyData
+		}
+		sizes[i] = len(data)
+		combined = append(combined, data...)
+	}
+
+	ranks, ties := rank(combined)
+	n := float64(len(combined))
+	correction := 1 - ties/(n*n*n-n)
+	if correction == 0 {
+		return nil, ErrNotEnoughData
+	}
+
+	h := 0.0
+	start := 0
+	for _, size := range sizes {
+		r := stats.Sum(ranks[start : start+size])
+		h += r * r / float64(size)
+		start += size
+	}
+	h = (12/(n*(n+1))*h - 3*(n+1)) / correction
+
+	dist, err := distuv.NewChiSquared(float64(k - 1))
+	if err != nil {
+		return nil, err
+	}
+
+	return &RankTestResult{
+		Statistic: h,
+		PValue:    dist.Survival(h),
+		DoF:       float64(k - 1),
+	}, nil
+}
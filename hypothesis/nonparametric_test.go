@@ -0,0 +1,194 @@
+package hypothesis
+
+import (
+	"math"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/jaumefe/stats"
+	randvar "github.com/jaumefe/stats/rand_var"
+)
+
+type rankTest struct {
+	name     string
+	test     func() (*RankTestResult, error)
+	expected RankTestResult
+}
+
+// Hamilton depression scale before (x) and after (y) treatment, from R's wilcox.test examples
+var (
+	depressionX = []float64{1.83, 0.50, 1.62, 2.48, 1.68, 1.88, 1.55, 3.06, 1.30}
+	depressionY = []float64{0.878, 0.647, 0.598, 2.05, 1.06, 1.29, 1.06, 3.14, 1.29}
+)
+
+// Reference values computed with R's wilcox.test and kruskal.test
+var rankTests = []rankTest{
+	{
+		name: "Mann-Whitney exact",
+		test: func() (*RankTestResult, error) {
+			x := []float64{0.80, 0.83, 1.89, 1.04, 1.45, 1.38, 1.91, 1.64, 0.73, 1.46}
+			y := []float64{1.15, 0.88, 0.90, 0.74, 1.21}
+			return MannWhitneyTest(x, y, &RankTestOptions{Alternative: Greater})
+		},
+		expected: RankTestResult{Statistic: 35, PValue: 0.1272061272061272, Exact: true},
+	},
+	{
+		name: "Mann-Whitney with ties",
+		test: func() (*RankTestResult, error) {
+			return MannWhitneyTest(randvar.NewRandVar(sleep1), randvar.NewRandVar(sleep2), nil)
+		},
+		expected: RankTestResult{Statistic: 25.5, PValue: 0.06933},
+	},
+	{
+		name: "Paired Wilcoxon exact",
+		test: func() (*RankTestResult, error) {
+			return PairedWilcoxonTest(depressionX, depressionY, &RankTestOptions{Alternative: Greater})
+		},
+		expected: RankTestResult{Statistic: 40, PValue: 0.01953125, Exact: true},
+	},
+	{
+		name: "Paired Wilcoxon with ties and zeros",
+		test: func() (*RankTestResult, error) {
+			return PairedWilcoxonTest([]float64{1, 2, 3, 4, 5, 6, 7, 8}, []float64{2, 4, 5, 4, 3, 9, 8, 10}, nil)
+		},
+		expected: RankTestResult{Statistic: 4.5, PValue: 0.1206454},
+	},
+	{
+		name: "Kruskal-Wallis",
+		test: func() (*RankTestResult, error) {
+			return KruskalWallisTest(
+				[]float64{2.9, 3.0, 2.5, 2.6, 3.2},
+				[]float64{3.8, 2.7, 4.0, 2.4},
+				[]float64{2.8, 3.4, 3.7, 2.2, 2.0},
+			)
+		},
+		expected: RankTestResult{Statistic: 0.77143, PValue: 0.68, DoF: 2},
+	},
+}
+
+func TestRankTests(t *testing.T) {
+	for _, tt := range rankTests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := tt.test()
+			if err != nil {
+				t.Fatalf("unexpected error received: %v", err)
+			}
+
+			if !closeTo(res.Statistic, tt.expected.Statistic, 5) {
+				t.Errorf("expected statistic: %v, got:%v", tt.expected.Statistic, res.Statistic)
+			}
+
+			if !closeTo(res.PValue, tt.expected.PValue, 4) {
+				t.Errorf("expected p-value: %v, got:%v", tt.expected.PValue, res.PValue)
+			}
+
+			if res.Exact != tt.expected.Exact {
+				t.Errorf("expected exact: %v, got:%v", tt.expected.Exact, res.Exact)
+			}
+
+			if res.DoF != tt.expected.DoF {
+				t.Errorf("expected degrees of freedom: %v, got:%v", tt.expected.DoF, res.DoF)
+			}
+		})
+	}
+}
+
+func TestRank(t *testing.T) {
+	ranks, ties := rank([]float64{3, 1, 4, 1, 5, 9, 2, 6, 5, 3, 5})
+	expected := []float64{4.5, 1.5, 6, 1.5, 8, 11, 3, 10, 8, 4.5, 8}
+	if !reflect.DeepEqual(ranks, expected) {
+		t.Errorf("expected ranks: %v, got:%v", expected, ranks)
+	}
+
+	// Two pairs and a triplet of ties
+	if ties != 6+6+24 {
+		t.Errorf("expected tie correction: %v, got:%v", 36, ties)
+	}
+}
+
+func TestExactDistributions(t *testing.T) {
+	for _, dist := range [][]float64{mannWhitneyDistribution(7, 4), signedRankDistribution(12)} {
+		if sum := stats.Sum(dist); math.Abs(sum-1) > 1e-12 {
+			t.Errorf("expected total probability: 1, got:%v", sum)
+		}
+
+		// Both null distributions are symmetric
+		for i := range dist {
+			if math.Abs(dist[i]-dist[len(dist)-1-i]) > 1e-15 {
+				t.Errorf("distribution is not symmetric: %v", dist)
+				break
+			}
+		}
+	}
+}
+
+func TestExactLimits(t *testing.T) {
+	// Samples too large for their exact distributions fall back to the normal approximation
+	x, y := make([]float64, 200), make([]float64, 1000)
+	for i := range x {
+		x[i] = float64(2*i) + 0.5
+	}
+	for i := range y {
+		y[i] = float64(i + 1)
+	}
+
+	exact := &RankTestOptions{Method: ExactPValue}
+	asymptotic := &RankTestOptions{Method: AsymptoticPValue}
+	for name, test := range map[string]func(*RankTestOptions) (*RankTestResult, error){
+		"Mann-Whitney": func(o *RankTestOptions) (*RankTestResult, error) { return MannWhitneyTest(x, y, o) },
+		"Wilcoxon":     func(o *RankTestOptions) (*RankTestResult, error) { return WilcoxonSignedRankTest(append(x, y...), o) },
+	} {
+		res, err := test(exact)
+		expected, _ := test(asymptotic)
+		if err != nil || res.Exact || res.PValue != expected.PValue {
+			t.Errorf("expected %v p-value from the normal approximation: %v, got:%v (exact %v, error %v)", name, expected.PValue, res.PValue, res.Exact, err)
+		}
+	}
+}
+
+func TestExactLopsided(t *testing.T) {
+	// Distributions are symmetric in the sample sizes
+	if !stats.Equals(mannWhitneyDistribution(3, 8), mannWhitneyDistribution(8, 3), 1e-15) {
+		t.Errorf("expected the same distribution for swapped sample sizes")
+	}
+
+	// Lopsided samples at the limit keep their exact distribution cheap
+	x, y := []float64{0.5, 1000.5}, make([]float64, 5000)
+	for i := range y {
+		y[i] = float64(i)
+	}
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	res, err := MannWhitneyTest(x, y, &RankTestOptions{Method: ExactPValue})
+	runtime.ReadMemStats(&after)
+	if err != nil || !res.Exact {
+		t.Fatalf("expected exact p-value, got exact %v (error %v)", res.Exact, err)
+	}
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 10<<20 {
+		t.Errorf("expected less than 10 MB allocated, got:%v bytes", allocated)
+	}
+}
+
+func TestRankTestErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"Mann-Whitney empty sample", second(MannWhitneyTest([]float64{}, []float64{1}, nil)), stats.ErrEmptyData},
+		{"Wilcoxon only zeros", second(WilcoxonSignedRankTest([]float64{2, 2}, &RankTestOptions{Mu: 2})), ErrNotEnoughData},
+		{"Paired Wilcoxon different lengths", second(PairedWilcoxonTest(depressionX, depressionY[1:], nil)), stats.ErrDifferentLength},
+		{"Kruskal-Wallis single group", second(KruskalWallisTest([]float64{1, 2})), ErrNotEnoughData},
+		{"Kruskal-Wallis all tied", second(KruskalWallisTest([]float64{1, 1}, []float64{1})), ErrNotEnoughData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err != tt.want {
+				t.Errorf("expected error: %v, got:%v", tt.want, tt.err)
+			}
+		})
+	}
+}
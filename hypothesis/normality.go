@@ -0,0 +1,248 @@
+package hypothesis
+
+import (
+	"math"
+	"slices"
+
+	"github.com/jaumefe/stats"
+	"github.com/jaumefe/stats/distuv"
+)
+
+/*
+Family defines the distribution family tested by the Anderson-Darling test,
+whose parameters are estimated from the sample:
+  - NormalFamily: normal distribution with unknown mean and variance
+  - ExponentialFamily: exponential distribution with unknown rate
+*/
+type Family int
+
+const (
+	NormalFamily Family = iota
+	ExponentialFamily
+)
+
+// Sample sizes supported by the Shapiro-Wilk test
+const (
+	shapiroWilkMinSize = 3
+	shapiroWilkMaxSize = 5000
+)
+
+// Returns the distribution of the family fitted to sorted data
+func fitFamily(data []float64, family Family) (distuv.Continuous, error) {
+	switch family {
+	case NormalFamily:
+		mean, err := stats.Mean(data)
+		if err != nil {
+			return nil, err
+		}
+
+		variance, err := stats.VarianceWith(data, &stats.Options{Estimator: stats.SampleEstimator})
+		if err != nil {
+			return nil, err
+		}
+		if variance == 0 {
+			return nil, stats.ErrNullStdDeviation
+		}
+		return distuv.NewNormal(mean, math.Sqrt(variance))
+	case ExponentialFamily:
+		if data[0] < 0 {
+			return nil, ErrOutOfSupport
+		}
+		mean, err := stats.Mean(data)
+		if err != nil {
+			return nil, err
+		}
+		if mean == 0 {
+			return nil, stats.ErrNullStdDeviation
+		}
+		return distuv.NewExponential(1 / mean)
+	default:
+		return nil, ErrUnknownFamily
+	}
+}
+
+/*
+AndersonDarlingTest tests whether a sample comes from the given distribution family.
+The statistic A² is computed on the fitted distribution and its p-value follows
+D'Agostino and Stephens (1986) for estimated parameters.
+It returns an error if the sample has less than 8 values, its values are all equal,
+some value lies outside of the support of the family or the family is unknown
+*/
+func AndersonDarlingTest[S Sample](sample S, family Family) (*GoodnessOfFitResult, error) {
+	data := slices.Clone(values(sample))
+	n := len(data)
+	if n == 0 {
+		return nil, stats.ErrEmptyData
+	}
+	if n < 8 {
+		return nil, ErrNotEnoughData
+	}
+	slices.Sort(data)
+
+	dist, err := fitFamily(data, family)
+	if err != nil {
+		return nil, err
+	}
+
+	// A² = -n - 1/n Σ (2i - 1) (log F(x_i) + log(1 - F(x_{n+1-i})))
+	fn := float64(n)
+	sum := 0.0
+	for i := 0; i < n; i++ {
+		sum += float64(2*i+1) * (math.Log(dist.CDF(data[i])) + math.Log(dist.Survival(data[n-1-i])))
+	}
+	a2 := -fn - sum/fn
+
+	var p float64
+	switch family {
+	case NormalFamily:
+		aa := a2 * (1 + 0.75/fn + 2.25/(fn*fn))
+		switch {
+		case aa < 0.2:
+			p = 1 - math.Exp(-13.436+101.14*aa-223.73*aa*aa)
+		case aa < 0.34:
+			p = 1 - math.Exp(-8.318+42.796*aa-59.938*aa*aa)
+		case aa < 0.6:
+			p = math.Exp(0.9177 - 4.279*aa - 1.38*aa*aa)
+		default:
+			p = math.Exp(1.2937 - 5.709*aa + 0.0186*aa*aa)
+		}
+	case ExponentialFamily:
+		aa := a2 * (1 + 0.6/fn)
+		switch {
+		case aa < 0.2:
+			p = 1 - math.Exp(-12.2204+67.459*aa-110.3*aa*aa)
+		case aa < 0.34:
+			p = 1 - math.Exp(-6.1327+20.218*aa-18.663*aa*aa)
+		case aa < 0.6:
+			p = math.Exp(0.9209 - 3.353*aa + 0.300*aa*aa)
+		default:
+			p = math.Exp(0.731 - 3.009*aa + 0.15*aa*aa)
+		}
+	}
+
+	return &GoodnessOfFitResult{Statistic: a2, PValue: min(1, max(0, p))}, nil
+}
+
+// Evaluates the polynomial c[0] + c[1]·x + c[2]·x² + ...
+func polynomial(c []float64, x float64) float64 {
+	res := 0.0
+	for i := len(c) - 1; i >= 0; i-- {
+		res = res*x + c[i]
+	}
+	return res
+}
+
+/*
+Returns the first half of the Shapiro-Wilk coefficients for a sample of size n,
+following Royston's (1995) approximation (algorithm AS R94)
+*/
+func shapiroWilkCoefficients(n int) []float64 {
+	half := n / 2
+	a := make([]float64, half)
+	if n == 3 {
+		a[0] = math.Sqrt(0.5)
+		return a
+	}
+
+	normal, _ := distuv.NewNormal(0, 1)
+	fn := float64(n)
+	m := make([]float64, half)
+	summ2 := 0.0
+	for i := range m {
+		m[i] = normal.Quantile((float64(i+1) - 0.375) / (fn + 0.25))
+		summ2 += m[i] * m[i]
+	}
+	summ2 *= 2
+	ssumm2 := math.Sqrt(summ2)
+	rsn := 1 / math.Sqrt(fn)
+
+	a[0] = polynomial([]float64{0, 0.221157, -0.147981, -2.07119, 4.434685, -2.706056}, rsn) - m[0]/ssumm2
+	first := 1
+	var fac float64
+	if n > 5 {
+		a[1] = polynomial([]float64{0, 0.042981, -0.293762, -1.752461, 5.682633, -3.582633}, rsn) - m[1]/ssumm2
+		first = 2
+		fac = math.Sqrt((summ2 - 2*m[0]*m[0] - 2*m[1]*m[1]) / (1 - 2*a[0]*a[0] - 2*a[1]*a[1]))
+	} else {
+		fac = math.Sqrt((summ2 - 2*m[0]*m[0]) / (1 - 2*a[0]*a[0]))
+	}
+
+	for i := first; i < half; i++ {
+		a[i] = -m[i] / fac
+	}
+
+	return a
+}
+
+/*
+ShapiroWilkTest tests whether a sample comes from a normal distribution.
+Coefficients and p-values follow Royston's (1995) approximation.
+It returns an error if the sample has less than 3 or more than 5000 values or all of them are equal
+*/
+func ShapiroWilkTest[S Sample](sample S) (*GoodnessOfFitResult, error) {
+	data := slices.Clone(values(sample))
+	n := len(data)
+	if n == 0 {
+		return nil, stats.ErrEmptyData
+	}
+	if n < shapiroWilkMinSize {
+		return nil, ErrNotEnoughData
+	}
+	if n > shapiroWilkMaxSize {
+		return nil, ErrTooMuchData
+	}
+	slices.Sort(data)
+
+	if data[n-1]-data[0] == 0 {
+		return nil, stats.ErrNullStdDeviation
+	}
+
+	a := shapiroWilkCoefficients(n)
+	num, ssa := 0.0, 0.0
+	for i, ai := range a {
+		num += ai * (data[n-1-i] - data[i])
+		ssa += 2 * ai * ai
+	}
+	variance, err := stats.VarianceWith(data, &stats.Options{Estimator: stats.SampleEstimator})
+	if err != nil {
+		return nil, err
+	}
+	ssx := variance * float64(n-1)
+
+	w := min(1, num*num/(ssa*ssx))
+	res := &GoodnessOfFitResult{Statistic: w}
+
+	if n == 3 {
+		// Exact distribution for 3 values
+		res.PValue = max(0, 6/math.Pi*(math.Asin(math.Sqrt(w))-math.Pi/3))
+		res.Exact = true
+		return res, nil
+	}
+
+	// log(1 - W) is approximately normal after a transformation depending on n
+	y := math.Log(1 - w)
+	fn := float64(n)
+	var mu, sigma float64
+	if n <= 11 {
+		gamma := polynomial([]float64{-2.273, 0.459}, fn)
+		if y >= gamma {
+			res.PValue = 0
+			return res, nil
+		}
+		y = -math.Log(gamma - y)
+		mu = polynomial([]float64{0.544, -0.39978, 0.025054, -6.714e-4}, fn)
+		sigma = math.Exp(polynomial([]float64{1.3822, -0.77857, 0.062767, -0.0020322}, fn))
+	} else {
+		ln := math.Log(fn)
+		mu = polynomial([]float64{-1.5861, -0.31082, -0.083751, 0.0038915}, ln)
+		sigma = math.Exp(polynomial([]float64{-0.4803, -0.082676, 0.0030302}, ln))
+	}
+
+	dist, err := distuv.NewNormal(mu, sigma)
+	if err != nil {
+		return nil, err
+	}
+
+	res.PValue = dist.Survival(y)
+	return res, nil
+}
@@ -0,0 +1,121 @@
+package hypothesis
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jaumefe/stats"
+	randvar "github.com/jaumefe/stats/rand_var"
+)
+
+// Fuel consumption (miles per gallon) of the 32 cars of R's mtcars dataset
+var mpg = []float64{
+	21.0, 21.0, 22.8, 21.4, 18.7, 18.1, 14.3, 24.4, 22.8, 19.2, 17.8, 16.4, 17.3, 15.2, 10.4, 10.4,
+	14.7, 32.4, 30.4, 33.9, 21.5, 15.5, 15.2, 13.3, 19.2, 27.3, 26.0, 30.4, 15.8, 19.7, 15.0, 21.4,
+}
+
+// Returns n values placed at the quantiles of an exponential distribution with the given mean
+func exponentialQuantiles(n int, mean float64) []float64 {
+	data := make([]float64, n)
+	for i := range data {
+		data[i] = -mean * math.Log(1-(float64(i)+0.5)/float64(n))
+	}
+	return data
+}
+
+// Reference values computed with R's shapiro.test and nortest::ad.test
+var normalityTests = []goodnessOfFitTest{
+	{
+		name:     "Anderson-Darling normal",
+		test:     func() (*GoodnessOfFitResult, error) { return AndersonDarlingTest(mpg, NormalFamily) },
+		expected: GoodnessOfFitResult{Statistic: 0.5797, PValue: 0.1207},
+	},
+	{
+		name: "Anderson-Darling exponential",
+		test: func() (*GoodnessOfFitResult, error) {
+			return AndersonDarlingTest(exponentialQuantiles(20, 3), ExponentialFamily)
+		},
+		expected: GoodnessOfFitResult{Statistic: 0.046524657478290266, PValue: 0.9999030220501013},
+	},
+	{
+		name:     "Anderson-Darling exponential on normal data",
+		test:     func() (*GoodnessOfFitResult, error) { return AndersonDarlingTest(mpg, ExponentialFamily) },
+		expected: GoodnessOfFitResult{Statistic: 7.5764, PValue: 0},
+	},
+	{
+		name:     "Shapiro-Wilk",
+		test:     func() (*GoodnessOfFitResult, error) { return ShapiroWilkTest(randvar.NewRandVar(mpg)) },
+		expected: GoodnessOfFitResult{Statistic: 0.94756, PValue: 0.1229},
+	},
+	{
+		name:     "Shapiro-Wilk small sample",
+		test:     func() (*GoodnessOfFitResult, error) { return ShapiroWilkTest(sleep1) },
+		expected: GoodnessOfFitResult{Statistic: 0.92581, PValue: 0.4079},
+	},
+	{
+		name:     "Shapiro-Wilk three values",
+		test:     func() (*GoodnessOfFitResult, error) { return ShapiroWilkTest([]float64{1, 2, 4}) },
+		expected: GoodnessOfFitResult{Statistic: 0.96429, PValue: 0.6369, Exact: true},
+	},
+}
+
+func TestNormality(t *testing.T) {
+	for _, tt := range normalityTests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := tt.test()
+			if err != nil {
+				t.Fatalf("unexpected error received: %v", err)
+			}
+
+			if !closeTo(res.Statistic, tt.expected.Statistic, 4) {
+				t.Errorf("expected statistic: %v, got:%v", tt.expected.Statistic, res.Statistic)
+			}
+
+			if math.Abs(res.PValue-tt.expected.PValue) > 1e-4 {
+				t.Errorf("expected p-value: %v, got:%v", tt.expected.PValue, res.PValue)
+			}
+
+			if res.Exact != tt.expected.Exact {
+				t.Errorf("expected exact: %v, got:%v", tt.expected.Exact, res.Exact)
+			}
+		})
+	}
+}
+
+func TestShapiroWilkCoefficients(t *testing.T) {
+	// Coefficients are normalized so that the whole antisymmetric vector has unit length
+	for _, n := range []int{4, 5, 6, 20, 101} {
+		sum := 0.0
+		for _, a := range shapiroWilkCoefficients(n) {
+			sum += 2 * a * a
+		}
+		if math.Abs(sum-1) > 1e-6 {
+			t.Errorf("expected squared norm for n = %v: 1, got:%v", n, sum)
+		}
+	}
+}
+
+func TestNormalityErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"Anderson-Darling empty data", second(AndersonDarlingTest([]float64{}, NormalFamily)), stats.ErrEmptyData},
+		{"Anderson-Darling small sample", second(AndersonDarlingTest(mpg[:7], NormalFamily)), ErrNotEnoughData},
+		{"Anderson-Darling constant data", second(AndersonDarlingTest(make([]float64, 8), NormalFamily)), stats.ErrNullStdDeviation},
+		{"Anderson-Darling negative data", second(AndersonDarlingTest(sleep1, ExponentialFamily)), ErrOutOfSupport},
+		{"Anderson-Darling unknown family", second(AndersonDarlingTest(mpg, Family(-1))), ErrUnknownFamily},
+		{"Shapiro-Wilk small sample", second(ShapiroWilkTest(mpg[:2])), ErrNotEnoughData},
+		{"Shapiro-Wilk large sample", second(ShapiroWilkTest(make([]float64, 5001))), ErrTooMuchData},
+		{"Shapiro-Wilk constant data", second(ShapiroWilkTest([]float64{1, 1, 1})), stats.ErrNullStdDeviation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err != tt.want {
+				t.Errorf("expected error: %v, got:%v", tt.want, tt.err)
+			}
+		})
+	}
+}
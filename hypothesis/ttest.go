@@ -0,0 +1,164 @@
+package hypothesis
+
+import (
+	"math"
+
+	"github.com/jaumefe/stats"
+	"github.com/jaumefe/stats/distuv"
+)
+
+/*
+TTestOptions to set special features of the t-tests:
+  - Alternative: alternative hypothesis, two-sided by default
+  - Mu: hypothesized mean (one-sample) or mean difference (two-sample and paired)
+  - ConfidenceLevel: level of the confidence interval, 0.95 by default
+  - EqualVariance: two-sample test pools both variances (Student) instead of using Welch's approximation
+*/
+type TTestOptions struct {
+	Alternative     Alternative
+	Mu              float64
+	ConfidenceLevel float64
+	EqualVariance   bool
+}
+
+/*
+TTestResult holds the outcome of a t-test:
+  - Statistic: t statistic
+  - DoF: degrees of freedom, which may be fractional for Welch's test
+  - PValue: p-value for the chosen alternative
+  - Estimate: mean (one-sample) or mean difference (two-sample and paired)
+  - StdErr: standard error of the estimate
+  - ConfidenceInterval: lower and upper bounds of the estimate, one of them infinite for one-sided alternatives
+*/
+type TTestResult struct {
+	Statistic          float64
+	DoF                float64
+	PValue             float64
+	Estimate           float64
+	StdErr             float64
+	ConfidenceInterval [2]float64
+}
+
+// Builds the result of a t-test given the estimate, its standard error and the degrees of freedom
+func tTestResult(estimate, stdErr, dof float64, opts *TTestOptions) (*TTestResult, error) {
+	if opts == nil {
+		opts = &TTestOptions{}
+	}
+
+	level, err := confidenceLevel(opts.ConfidenceLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	if stdErr == 0 {
+		return nil, stats.ErrNullStdDeviation
+	}
+
+	dist, err := distuv.NewStudentsT(dof)
+	if err != nil {
+		return nil, err
+	}
+
+	statistic := (estimate - opts.Mu) / stdErr
+	res := &TTestResult{
+		Statistic: statistic,
+		DoF:       dof,
+		PValue:    pValue(dist, statistic, opts.Alternative),
+		Estimate:  estimate,
+		StdErr:    stdErr,
+	}
+
+	switch opts.Alternative {
+	case Less:
+		res.ConfidenceInterval = [2]float64{math.Inf(-1), estimate + dist.Quantile(level)*stdErr}
+	case Greater:
+		res.ConfidenceInterval = [2]float64{estimate - dist.Quantile(level)*stdErr, math.Inf(1)}
+	default:
+		q := dist.Quantile(1 - (1-level)/2)
+		res.ConfidenceInterval = [2]float64{estimate - q*stdErr, estimate + q*stdErr}
+	}
+
+	return res, nil
+}
+
+/*
+OneSampleTTest tests whether the mean of a sample equals opts.Mu.
+It returns an error if the sample has less than 2 values or its standard deviation is null
+*/
+func OneSampleTTest[S Sample](sample S, opts *TTestOptions) (*TTestResult, error) {
+	data := values(sample)
+	if len(data) == 1 {
+		return nil, ErrNotEnoughData
+	}
+
+	mean, err := stats.Mean(data)
+	if err != nil {
+		return nil, err
+	}
+
+	variance, err := stats.VarianceWith(data, &stats.Options{Estimator: stats.SampleEstimator})
+	if err != nil {
+		return nil, err
+	}
+
+	n := float64(len(data))
+	return tTestResult(mean, math.Sqrt(variance/n), n-1, opts)
+}
+
+/*
+TwoSampleTTest tests whether the difference between the means of two independent samples (x - y) equals opts.Mu.
+By default Welch's test is performed; set opts.EqualVariance to pool the variances (Student's test).
+It returns an error if any sample has less than 2 values or both standard deviations are null
+*/
+func TwoSampleTTest[S Sample](x, y S, opts *TTestOptions) (*TTestResult, error) {
+	dataX, dataY := values(x), values(y)
+	if len(dataX) == 1 || len(dataY) == 1 {
+		return nil, ErrNotEnoughData
+	}
+
+	meanX, err := stats.Mean(dataX)
+	if err != nil {
+		return nil, err
+	}
+
+	meanY, err := stats.Mean(dataY)
+	if err != nil {
+		return nil, err
+	}
+
+	sample := &stats.Options{Estimator: stats.SampleEstimator}
+	varX, _ := stats.VarianceWith(dataX, sample)
+	varY, _ := stats.VarianceWith(dataY, sample)
+
+	nx, ny := float64(len(dataX)), float64(len(dataY))
+	estimate := meanX - meanY
+
+	if opts != nil && opts.EqualVariance {
+		dof := nx + ny - 2
+		pooled := ((nx-1)*varX + (ny-1)*varY) / dof
+		return tTestResult(estimate, math.Sqrt(pooled*(1/nx+1/ny)), dof, opts)
+	}
+
+	seX, seY := varX/nx, varY/ny
+	stdErr := math.Sqrt(seX + seY)
+	dof := (seX + seY) * (seX + seY) / (seX*seX/(nx-1) + seY*seY/(ny-1))
+	return tTestResult(estimate, stdErr, dof, opts)
+}
+
+/*
+PairedTTest tests whether the mean of the differences between paired samples (x - y) equals opts.Mu.
+It returns an error if samples have different lengths, less than 2 pairs or the differences are constant
+*/
+func PairedTTest[S Sample](x, y S, opts *TTestOptions) (*TTestResult, error) {
+	dataX, dataY := values(x), values(y)
+	if len(dataX) != len(dataY) {
+		return nil, stats.ErrDifferentLength
+	}
+
+	diff := make([]float64, len(dataX))
+	for i := range dataX {
+		diff[i] = dataX[i] - dataY[i]
+	}
+
+	return OneSampleTTest(diff, opts)
+}
@@ -0,0 +1,145 @@
+package hypothesis
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jaumefe/stats"
+	randvar "github.com/jaumefe/stats/rand_var"
+)
+
+// Student's sleep data: extra hours of sleep for two soporific drugs on the same 10 patients
+var (
+	sleep1 = []float64{0.7, -1.6, -0.2, -1.2, -0.1, 3.4, 3.7, 0.8, 0.0, 2.0}
+	sleep2 = []float64{1.9, 0.8, 1.1, 0.1, -0.1, 4.4, 5.5, 1.6, 4.6, 3.4}
+)
+
+type tTestCase struct {
+	name     string
+	test     func() (*TTestResult, error)
+	expected TTestResult
+}
+
+// Reference values computed with R's t.test
+var tTestCases = []tTestCase{
+	{
+		name: "One sample",
+		test: func() (*TTestResult, error) { return OneSampleTTest(sleep1, nil) },
+		expected: TTestResult{
+			Statistic: 1.3257, DoF: 9, PValue: 0.2176, Estimate: 0.75,
+			ConfidenceInterval: [2]float64{-0.5297804, 2.0297804},
+		},
+	},
+	{
+		name: "One sample from a random variable",
+		test: func() (*TTestResult, error) { return OneSampleTTest(randvar.NewRandVar(sleep1), nil) },
+		expected: TTestResult{
+			Statistic: 1.3257, DoF: 9, PValue: 0.2176, Estimate: 0.75,
+			ConfidenceInterval: [2]float64{-0.5297804, 2.0297804},
+		},
+	},
+	{
+		name: "Welch two sample",
+		test: func() (*TTestResult, error) { return TwoSampleTTest(sleep1, sleep2, nil) },
+		expected: TTestResult{
+			Statistic: -1.8608, DoF: 17.776, PValue: 0.07939, Estimate: -1.58,
+			ConfidenceInterval: [2]float64{-3.3654832, 0.2054832},
+		},
+	},
+	{
+		name: "Pooled two sample",
+		test: func() (*TTestResult, error) {
+			return TwoSampleTTest(sleep1, sleep2, &TTestOptions{EqualVariance: true})
+		},
+		expected: TTestResult{
+			Statistic: -1.8608, DoF: 18, PValue: 0.07919, Estimate: -1.58,
+			ConfidenceInterval: [2]float64{-3.363874, 0.203874},
+		},
+	},
+	{
+		name: "Paired",
+		test: func() (*TTestResult, error) { return PairedTTest(sleep1, sleep2, nil) },
+		expected: TTestResult{
+			Statistic: -4.0621, DoF: 9, PValue: 0.002833, Estimate: -1.58,
+			ConfidenceInterval: [2]float64{-2.4598858, -0.7001142},
+		},
+	},
+	{
+		name: "Paired one-sided",
+		test: func() (*TTestResult, error) {
+			return PairedTTest(sleep1, sleep2, &TTestOptions{Alternative: Less})
+		},
+		expected: TTestResult{
+			Statistic: -4.0621, DoF: 9, PValue: 0.001416, Estimate: -1.58,
+			ConfidenceInterval: [2]float64{math.Inf(-1), -0.8669947},
+		},
+	},
+}
+
+// Returns whether got agrees with the expected value up to its number of significant digits
+func closeTo(got, expected float64, digits int) bool {
+	if math.IsInf(expected, 0) {
+		return got == expected
+	}
+
+	return math.Abs(got-expected) <= 5*math.Pow(10, -float64(digits))*math.Max(1, math.Abs(expected))
+}
+
+func TestTTest(t *testing.T) {
+	for _, tt := range tTestCases {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := tt.test()
+			if err != nil {
+				t.Fatalf("unexpected error received: %v", err)
+			}
+
+			if !closeTo(res.Statistic, tt.expected.Statistic, 4) {
+				t.Errorf("expected statistic: %v, got:%v", tt.expected.Statistic, res.Statistic)
+			}
+
+			if !closeTo(res.DoF, tt.expected.DoF, 3) {
+				t.Errorf("expected degrees of freedom: %v, got:%v", tt.expected.DoF, res.DoF)
+			}
+
+			if math.Abs(res.PValue-tt.expected.PValue) > 1e-5 {
+				t.Errorf("expected p-value: %v, got:%v", tt.expected.PValue, res.PValue)
+			}
+
+			if math.Abs(res.Estimate-tt.expected.Estimate) > 1e-12 {
+				t.Errorf("expected estimate: %v, got:%v", tt.expected.Estimate, res.Estimate)
+			}
+
+			for i, bound := range res.ConfidenceInterval {
+				if !closeTo(bound, tt.expected.ConfidenceInterval[i], 6) {
+					t.Errorf("expected confidence interval: %v, got:%v", tt.expected.ConfidenceInterval, res.ConfidenceInterval)
+				}
+			}
+		})
+	}
+}
+
+func TestTTestErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"Empty data", second(OneSampleTTest([]float64{}, nil)), stats.ErrEmptyData},
+		{"Single value", second(OneSampleTTest([]float64{1}, nil)), ErrNotEnoughData},
+		{"Constant data", second(OneSampleTTest([]float64{1, 1, 1}, nil)), stats.ErrNullStdDeviation},
+		{"Different lengths", second(PairedTTest(sleep1, sleep2[1:], nil)), stats.ErrDifferentLength},
+		{"Invalid confidence level", second(TwoSampleTTest(sleep1, sleep2, &TTestOptions{ConfidenceLevel: 1})), ErrInvalidConfidenceLevel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err != tt.want {
+				t.Errorf("expected error: %v, got:%v", tt.want, tt.err)
+			}
+		})
+	}
+}
+
+func second[T any](_ T, err error) error {
+	return err
+}
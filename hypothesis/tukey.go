@@ -0,0 +1,266 @@
+package hypothesis
+
+import (
+	"math"
+
+	"github.com/jaumefe/stats/distuv"
+	"github.com/jaumefe/stats/mathext"
+	randvar "github.com/jaumefe/stats/rand_var"
+)
+
+// Gauss-Legendre nodes and weights (half of them, as they are symmetric) used to integrate the studentized range
+var (
+	rangeNodes = []float64{
+		0.981560634246719250690549090149, 0.904117256370474856678465866119,
+		0.769902674194304687036893833213, 0.587317954286617447296702418941,
+		0.367831498998180193752691536644, 0.125233408511468915472441369464,
+	}
+	rangeWeights = []float64{
+		0.047175336386511827194615961485, 0.106939325995318430960254718194,
+		0.160078328543346226334652529543, 0.203167426723065921749064455810,
+		0.233492536538354808760849898925, 0.249147045813402785000562436043,
+	}
+	dofNodes = []float64{
+		0.989400934991649932596154173450, 0.944575023073232576077988415535,
+		0.865631202387831743880467897712, 0.755404408355003033895101194847,
+		0.617876244402643748446671764049, 0.458016777657227386342419442984,
+		0.281603550779258913230460501460, 0.950125098376374401853193354250e-1,
+	}
+	dofWeights = []float64{
+		0.271524594117540948517805724560e-1, 0.622535239386478928628438369944e-1,
+		0.951585116824927848099251076022e-1, 0.124628971255533872052476282192,
+		0.149595988816576732081501730547, 0.169156519395002538189312079030,
+		0.182603415044923588866763667969, 0.189450610455068496285396723208,
+	}
+)
+
+/*
+Returns the probability that the range of k standard normal values is less than w,
+following Copenhaver and Holland (1988)
+*/
+func normalRangeCDF(w float64, k int) float64 {
+	const (
+		upper  = 8.0
+		minExp = -30.0
+		maxSq  = 60.0
+	)
+
+	half := w / 2
+	if half >= upper {
+		return 1
+	}
+
+	normal, _ := distuv.NewNormal(0, 1)
+	fk := float64(k)
+
+	// Probability of all values lying within (-w/2, w/2), plus the integral over the lowest value
+	p := math.Pow(2*normal.CDF(half)-1, fk)
+
+	intervals := 3
+	if w > 3 {
+		intervals = 2
+	}
+	step := (upper - half) / float64(intervals)
+	lower := half
+	for i := 0; i < intervals; i++ {
+		a := lower + step/2
+		b := step / 2
+		sum := 0.0
+		for j := 0; j < 2*len(rangeNodes); j++ {
+			var x, weight float64
+			if j < len(rangeNodes) {
+				x, weight = -rangeNodes[j], rangeWeights[j]
+			} else {
+				x, weight = rangeNodes[2*len(rangeNodes)-1-j], rangeWeights[2*len(rangeNodes)-1-j]
+			}
+
+			ac := a + b*x
+			if ac*ac > maxSq {
+				break
+			}
+
+			inner := normal.CDF(ac) - normal.CDF(ac-w)
+			if inner >= math.Exp(minExp/(fk-1)) {
+				sum += weight * math.Exp(-ac*ac/2) * math.Pow(inner, fk-1)
+			}
+		}
+
+		p += sum * 2 * b * fk / math.Sqrt(2*math.Pi)
+		lower += step
+	}
+
+	return min(1, p)
+}
+
+/*
+Returns the probability of the studentized range of k groups with df degrees of freedom being less than q,
+integrating the normal range over the distribution of the standard deviation estimate
+*/
+func studentizedRangeCDF(q float64, k int, df float64) float64 {
+	const (
+		minExp = -30.0
+		tol    = 1e-14
+	)
+
+	if q <= 0 {
+		return 0
+	}
+	if df > 25000 {
+		return normalRangeCDF(q, k)
+	}
+
+	var length float64
+	switch {
+	case df <= 100:
+		length = 1
+	case df <= 800:
+		length = 0.5
+	case df <= 5000:
+		length = 0.25
+	default:
+		length = 0.125
+	}
+
+	f2 := df / 2
+	logConst := f2*math.Log(df) - df*math.Ln2 - mathext.Lgamma(f2) + math.Log(length)
+
+	p := 0.0
+	for i := 1; i <= 50; i++ {
+		sum := 0.0
+		center := float64(2*i-1) * length
+		for j := 0; j < 2*len(dofNodes); j++ {
+			var u, weight float64
+			if j < len(dofNodes) {
+				u, weight = center-dofNodes[j]*length, dofWeights[j]
+			} else {
+				u, weight = center+dofNodes[j-len(dofNodes)]*length, dofWeights[j-len(dofNodes)]
+			}
+
+			t := logConst + (f2-1)*math.Log(u) - u*df/4
+			if t >= minExp {
+				sum += normalRangeCDF(q*math.Sqrt(u/2), k) * weight * math.Exp(t)
+			}
+		}
+
+		if float64(i)*length >= 1 && sum <= tol {
+			break
+		}
+		p += sum
+	}
+
+	return min(1, p)
+}
+
+/*
+Returns the value q such that the studentized range CDF equals p, refining the
+initial approximation of Odeh and Evans (1974) through the secant method
+*/
+func studentizedRangeQuantile(p float64, k int, df float64) float64 {
+	// Initial approximation
+	ps := 0.5 - p/2
+	y := math.Sqrt(math.Log(1 / (ps * ps)))
+	t := y + ((((y*-0.453642210148e-04-0.204231210125)*y-0.342242088547)*y-1)*y+0.322232421088)/
+		((((y*0.38560700634e-02+0.103537752850)*y+0.531103462366)*y+0.588581570495)*y+0.993484626060e-01)
+	if df < 120 {
+		t += (t*t*t + t) / df / 4
+	}
+	c := 0.8832 - 0.2368*t
+	if df < 120 {
+		c += -1.214/df + 1.208*t/df
+	}
+	x0 := t * (c*math.Log(float64(k-1)) + 1.4142)
+
+	f0 := studentizedRangeCDF(x0, k, df) - p
+	x1 := x0 + 1
+	if f0 > 0 {
+		x1 = max(0, x0-1)
+	}
+
+	for i := 0; i < 50; i++ {
+		f1 := studentizedRangeCDF(x1, k, df) - p
+		if f1 == f0 {
+			break
+		}
+
+		x0, x1 = x1, x1-f1*(x1-x0)/(f1-f0)
+		f0 = f1
+		x1 = max(0, x1)
+		if math.Abs(x1-x0) < 1e-10 {
+			break
+		}
+	}
+
+	return x1
+}
+
+/*
+TukeyOptions to set special features of Tukey's HSD test:
+  - ConfidenceLevel: confidence level of the simultaneous intervals, 95% by default
+*/
+type TukeyOptions struct {
+	ConfidenceLevel float64
+}
+
+/*
+TukeyComparison holds the comparison of the means of two groups:
+  - First and Second: categories of the compared groups
+  - Diff: mean of the second group minus mean of the first one
+  - ConfidenceInterval: simultaneous confidence interval of the difference
+  - PValue: p-value adjusted for all the pairwise comparisons
+*/
+type TukeyComparison struct {
+	First              string
+	Second             string
+	Diff               float64
+	ConfidenceInterval [2]float64
+	PValue             float64
+}
+
+/*
+TukeyHSD performs Tukey's honestly significant difference test on all the pairs of groups,
+where groups are made of the variables sharing a category, in order of appearance.
+Unequal group sizes are handled through the Tukey-Kramer method.
+It returns an error in the same cases as OneWayANOVA or if the confidence level is not valid
+*/
+func TukeyHSD(vars []*randvar.AdvRandVar, opts *TukeyOptions) ([]TukeyComparison, error) {
+	if opts == nil {
+		opts = &TukeyOptions{}
+	}
+
+	level, err := confidenceLevel(opts.ConfidenceLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := groupByCategory(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	table, err := oneWayANOVA(groups)
+	if err != nil {
+		return nil, err
+	}
+
+	k := len(groups)
+	df := table.Residuals.DoF
+	mse := table.Residuals.MS
+	width := studentizedRangeQuantile(level, k, df)
+
+	comparisons := make([]TukeyComparison, 0, k*(k-1)/2)
+	for i := 0; i < k; i++ {
+		for j := i + 1; j < k; j++ {
+			diff := groups[j].mean - groups[i].mean
+			se := math.Sqrt(mse / 2 * (1/float64(len(groups[i].data)) + 1/float64(len(groups[j].data))))
+			comparisons = append(comparisons, TukeyComparison{
+				First:              groups[i].level,
+				Second:             groups[j].level,
+				Diff:               diff,
+				ConfidenceInterval: [2]float64{diff - width*se, diff + width*se},
+				PValue:             1 - studentizedRangeCDF(math.Abs(diff)/se, k, df),
+			})
+		}
+	}
+
+	return comparisons, nil
+}
@@ -0,0 +1,91 @@
+package hypothesis
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jaumefe/stats/distuv"
+	randvar "github.com/jaumefe/stats/rand_var"
+)
+
+func TestTukeyHSD(t *testing.T) {
+	comparisons, err := TukeyHSD(plantGrowth, nil)
+	if err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+
+	// Reference values computed with R's TukeyHSD
+	expected := []TukeyComparison{
+		{First: "ctrl", Second: "trt1", Diff: -0.371, ConfidenceInterval: [2]float64{-1.0622161, 0.3202161}, PValue: 0.3908711},
+		{First: "ctrl", Second: "trt2", Diff: 0.494, ConfidenceInterval: [2]float64{-0.1972161, 1.1852161}, PValue: 0.1979960},
+		{First: "trt1", Second: "trt2", Diff: 0.865, ConfidenceInterval: [2]float64{0.1737839, 1.5562161}, PValue: 0.0120064},
+	}
+
+	if len(comparisons) != len(expected) {
+		t.Fatalf("expected comparisons: %v, got:%v", expected, comparisons)
+	}
+
+	for i, c := range comparisons {
+		e := expected[i]
+		if c.First != e.First || c.Second != e.Second {
+			t.Errorf("expected groups: %v-%v, got:%v-%v", e.Second, e.First, c.Second, c.First)
+		}
+
+		if math.Abs(c.Diff-e.Diff) > 1e-12 {
+			t.Errorf("expected difference: %v, got:%v", e.Diff, c.Diff)
+		}
+
+		for j, bound := range c.ConfidenceInterval {
+			if math.Abs(bound-e.ConfidenceInterval[j]) > 1e-6 {
+				t.Errorf("expected confidence interval: %v, got:%v", e.ConfidenceInterval, c.ConfidenceInterval)
+			}
+		}
+
+		if math.Abs(c.PValue-e.PValue) > 1e-6 {
+			t.Errorf("expected p-value: %v, got:%v", e.PValue, c.PValue)
+		}
+	}
+}
+
+func TestStudentizedRange(t *testing.T) {
+	// The range of 2 values is √2 times the absolute value of a Student's t
+	for _, df := range []float64{3, 10, 50} {
+		tdist, _ := distuv.NewStudentsT(df)
+		for _, q := range []float64{0.5, 2, 4.5} {
+			expected := 2*tdist.CDF(q/math.Sqrt2) - 1
+			if got := studentizedRangeCDF(q, 2, df); math.Abs(got-expected) > 1e-8 {
+				t.Errorf("expected CDF(%v) with %v degrees of freedom: %v, got:%v", q, df, expected, got)
+			}
+		}
+	}
+
+	// Large degrees of freedom tend to the range of normal values
+	if q := studentizedRangeQuantile(0.95, 2, 1e6); math.Abs(q-2.771808) > 1e-5 {
+		t.Errorf("expected quantile: %v, got:%v", 2.771808, q)
+	}
+
+	for _, p := range []float64{0.5, 0.9, 0.99} {
+		if got := studentizedRangeCDF(studentizedRangeQuantile(p, 5, 12), 5, 12); math.Abs(got-p) > 1e-8 {
+			t.Errorf("CDF(Quantile(%v)) = %v", p, got)
+		}
+	}
+}
+
+func TestTukeyHSDErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"Invalid confidence level", second(TukeyHSD(plantGrowth, &TukeyOptions{ConfidenceLevel: 2})), ErrInvalidConfidenceLevel},
+		{"Single group", second(TukeyHSD([]*randvar.AdvRandVar{plantGrowth[0], plantGrowth[2]}, nil)), ErrNotEnoughData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err != tt.want {
+				t.Errorf("expected error: %v, got:%v", tt.want, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,5 @@
+/*
+Package interval provides confidence intervals for the mean, proportions, the variance,
+the median and percentiles, all of them parameterized by their confidence level.
+*/
+package interval
@@ -0,0 +1,10 @@
+package interval
+
+import "errors"
+
+var (
+	ErrInvalidConfidenceLevel = errors.New("confidence level must be between 0 and 1")
+	ErrNotEnoughData          = errors.New("not enough data to compute the interval")
+	ErrInvalidTrials          = errors.New("number of trials must be greater than 0")
+	ErrInvalidSuccesses       = errors.New("number of successes must be between 0 and the number of trials")
+)
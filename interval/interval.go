@@ -0,0 +1,284 @@
+package interval
+
+import (
+	"math"
+
+	"github.com/jaumefe/stats"
+	"github.com/jaumefe/stats/distuv"
+)
+
+/*
+Interval holds a confidence interval:
+  - Estimate: point estimate of the parameter
+  - Lower and Upper: bounds of the interval
+  - Level: confidence level of the interval. Order statistic based intervals report the one
+    actually achieved, which is never below the requested level unless the sample is too small
+*/
+type Interval struct {
+	Estimate float64
+	Lower    float64
+	Upper    float64
+	Level    float64
+}
+
+// Returns whether a confidence level is valid
+func validLevel(level float64) bool {
+	return level > 0 && level < 1
+}
+
+// Returns the two-sided critical value of the standard normal distribution for a confidence level
+func zCritical(level float64) float64 {
+	normal, _ := distuv.NewNormal(0, 1)
+	return normal.Quantile(1 - (1-level)/2)
+}
+
+/*
+MeanT computes the Student's t confidence interval of the mean of a []float64 data input,
+for an unknown population variance.
+It returns an error if the confidence level is not valid or there are less than 2 values
+*/
+func MeanT(data []float64, level float64) (*Interval, error) {
+	if !validLevel(level) {
+		return nil, ErrInvalidConfidenceLevel
+	}
+
+	if len(data) == 1 {
+		return nil, ErrNotEnoughData
+	}
+
+	mean, err := stats.Mean(data)
+	if err != nil {
+		return nil, err
+	}
+
+	variance, err := stats.VarianceWith(data, &stats.Options{Estimator: stats.SampleEstimator})
+	if err != nil {
+		return nil, err
+	}
+
+	n := float64(len(data))
+	dist, err := distuv.NewStudentsT(n - 1)
+	if err != nil {
+		return nil, err
+	}
+
+	half := dist.Quantile(1-(1-level)/2) * math.Sqrt(variance/n)
+	return &Interval{Estimate: mean, Lower: mean - half, Upper: mean + half, Level: level}, nil
+}
+
+/*
+MeanZ computes the normal confidence interval of the mean of a []float64 data input,
+given the population standard deviation sigma.
+It returns an error if the confidence level is not valid, data is empty or sigma is not greater than 0
+*/
+func MeanZ(data []float64, sigma, level float64) (*Interval, error) {
+	if !validLevel(level) {
+		return nil, ErrInvalidConfidenceLevel
+	}
+
+	if !(sigma > 0) {
+		return nil, stats.ErrNullStdDeviation
+	}
+
+	mean, err := stats.Mean(data)
+	if err != nil {
+		return nil, err
+	}
+
+	half := zCritical(level) * sigma / math.Sqrt(float64(len(data)))
+	return &Interval{Estimate: mean, Lower: mean - half, Upper: mean + half, Level: level}, nil
+}
+
+// Checks the arguments of the proportion intervals
+func checkProportion(successes, trials int, level float64) error {
+	if !validLevel(level) {
+		return ErrInvalidConfidenceLevel
+	}
+
+	if trials < 1 {
+		return ErrInvalidTrials
+	}
+
+	if successes < 0 || successes > trials {
+		return ErrInvalidSuccesses
+	}
+
+	return nil
+}
+
+/*
+ProportionWilson computes the Wilson score confidence interval of a proportion
+given the number of successes out of a number of trials.
+It returns an error if the confidence level is not valid, trials are not positive
+or successes are out of range (0 - trials)
+*/
+func ProportionWilson(successes, trials int, level float64) (*Interval, error) {
+	if err := checkProportion(successes, trials, level); err != nil {
+		return nil, err
+	}
+
+	n := float64(trials)
+	p := float64(successes) / n
+	z := zCritical(level)
+	z2 := z * z
+
+	center := (p + z2/(2*n)) / (1 + z2/n)
+	half := z / (1 + z2/n) * math.Sqrt(p*(1-p)/n+z2/(4*n*n))
+	return &Interval{
+		Estimate: p,
+		Lower:    math.Max(0, center-half),
+		Upper:    math.Min(1, center+half),
+		Level:    level,
+	}, nil
+}
+
+/*
+ProportionClopperPearson computes the exact Clopper-Pearson confidence interval of a proportion
+given the number of successes out of a number of trials, which is conservative.
+It returns an error if the confidence level is not valid, trials are not positive
+or successes are out of range (0 - trials)
+*/
+func ProportionClopperPearson(successes, trials int, level float64) (*Interval, error) {
+	if err := checkProportion(successes, trials, level); err != nil {
+		return nil, err
+	}
+
+	alpha := 1 - level
+	res := &Interval{Estimate: float64(successes) / float64(trials), Lower: 0, Upper: 1, Level: level}
+
+	if successes > 0 {
+		b, err := distuv.NewBeta(float64(successes), float64(trials-successes+1))
+		if err != nil {
+			return nil, err
+		}
+		res.Lower = b.Quantile(alpha / 2)
+	}
+
+	if successes < trials {
+		b, err := distuv.NewBeta(float64(successes+1), float64(trials-successes))
+		if err != nil {
+			return nil, err
+		}
+		res.Upper = b.Quantile(1 - alpha/2)
+	}
+
+	return res, nil
+}
+
+/*
+ProportionAgrestiCoull computes the Agresti-Coull confidence interval of a proportion
+given the number of successes out of a number of trials.
+It returns an error if the confidence level is not valid, trials are not positive
+or successes are out of range (0 - trials)
+*/
+func ProportionAgrestiCoull(successes, trials int, level float64) (*Interval, error) {
+	if err := checkProportion(successes, trials, level); err != nil {
+		return nil, err
+	}
+
+	z := zCritical(level)
+	n := float64(trials) + z*z
+	p := (float64(successes) + z*z/2) / n
+	half := z * math.Sqrt(p*(1-p)/n)
+	return &Interval{
+		Estimate: float64(successes) / float64(trials),
+		Lower:    math.Max(0, p-half),
+		Upper:    math.Min(1, p+half),
+		Level:    level,
+	}, nil
+}
+
+/*
+Variance computes the chi-square confidence interval of the variance of a []float64 data input,
+assuming it comes from a normal distribution. The estimate is the sample variance (n - 1 denominator).
+It returns an error if the confidence level is not valid or there are less than 2 values
+*/
+func Variance(data []float64, level float64) (*Interval, error) {
+	if !validLevel(level) {
+		return nil, ErrInvalidConfidenceLevel
+	}
+
+	if len(data) == 1 {
+		return nil, ErrNotEnoughData
+	}
+
+	variance, err := stats.VarianceWith(data, &stats.Options{Estimator: stats.SampleEstimator})
+	if err != nil {
+		return nil, err
+	}
+
+	dof := float64(len(data) - 1)
+	dist, err := distuv.NewChiSquared(dof)
+	if err != nil {
+		return nil, err
+	}
+
+	alpha := 1 - level
+	return &Interval{
+		Estimate: variance,
+		Lower:    dof * variance / dist.Quantile(1-alpha/2),
+		Upper:    dof * variance / dist.Quantile(alpha/2),
+		Level:    level,
+	}, nil
+}
+
+/*
+Percentile computes a distribution-free confidence interval of the percentile of a []float64 data input
+given a percentage(%) value. Bounds are order statistics chosen through the binomial distribution of the
+number of values below the percentile, so the achieved level, reported in the interval, is at least the
+requested one unless the sample is too small, in which case bounds are the sample extremes.
+It returns an error if the confidence level is not valid, data is empty or the percentage is out of range (0 - 100%)
+*/
+func Percentile(data []float64, p, level float64) (*Interval, error) {
+	if !validLevel(level) {
+		return nil, ErrInvalidConfidenceLevel
+	}
+
+	estimate, err := stats.Percentile(data, p)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(data)
+	sorted := stats.Sort(data)
+	binomial, err := distuv.NewBinomial(n, p/100)
+	if err != nil {
+		return nil, err
+	}
+
+	// P(x_(l) <= ξ <= x_(u)) = P(l <= B <= u - 1), with 1-indexed order statistics
+	alpha := 1 - level
+	k, err := binomial.Quantile(alpha / 2)
+	if err != nil {
+		return nil, err
+	}
+	l := k + 1
+	if binomial.CDF(k) > alpha/2 {
+		l = k
+	}
+
+	k, err = binomial.Quantile(1 - alpha/2)
+	if err != nil {
+		return nil, err
+	}
+	u := k + 1
+
+	l = max(1, l)
+	u = min(n, u)
+
+	return &Interval{
+		Estimate: estimate,
+		Lower:    sorted[l-1],
+		Upper:    sorted[u-1],
+		Level:    binomial.CDF(u-1) - binomial.CDF(l-1),
+	}, nil
+}
+
+/*
+Median computes a distribution-free confidence interval of the median of a []float64 data input,
+as the 50th percentile.
+It returns an error if the confidence level is not valid or data is empty
+*/
+func Median(data []float64, level float64) (*Interval, error) {
+	return Percentile(data, 50, level)
+}
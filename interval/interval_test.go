@@ -0,0 +1,142 @@
+package interval
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jaumefe/stats"
+	"github.com/jaumefe/stats/distuv"
+)
+
+// Student's sleep data: extra hours of sleep given by a soporific drug on 10 patients
+var sleep = []float64{0.7, -1.6, -0.2, -1.2, -0.1, 3.4, 3.7, 0.8, 0.0, 2.0}
+
+type intervalTest struct {
+	name     string
+	interval func() (*Interval, error)
+	expected Interval
+	epsilon  float64
+}
+
+var intervalTests = []intervalTest{
+	{
+		name:     "Mean t",
+		interval: func() (*Interval, error) { return MeanT(sleep, 0.95) },
+		expected: Interval{Estimate: 0.75, Lower: -0.5297804, Upper: 2.0297804, Level: 0.95},
+		epsilon:  1e-7,
+	},
+	{
+		name:     "Mean z",
+		interval: func() (*Interval, error) { return MeanZ(sleep, 2, 0.95) },
+		expected: Interval{Estimate: 0.75, Lower: -0.48959006460912313, Upper: 1.9895900646091231, Level: 0.95},
+		epsilon:  1e-12,
+	},
+	{
+		name:     "Proportion Wilson",
+		interval: func() (*Interval, error) { return ProportionWilson(15, 50, 0.95) },
+		expected: Interval{Estimate: 0.3, Lower: 0.19103553500880951, Upper: 0.437503504644534, Level: 0.95},
+		epsilon:  1e-12,
+	},
+	{
+		name:     "Proportion Agresti-Coull",
+		interval: func() (*Interval, error) { return ProportionAgrestiCoull(15, 50, 0.95) },
+		expected: Interval{Estimate: 0.3, Lower: 0.1902707034234704, Upper: 0.43826833622987316, Level: 0.95},
+		epsilon:  1e-12,
+	},
+	{
+		name:     "Proportion Clopper-Pearson without successes",
+		interval: func() (*Interval, error) { return ProportionClopperPearson(0, 20, 0.9) },
+		expected: Interval{Estimate: 0, Lower: 0, Upper: 1 - math.Pow(0.05, 1.0/20), Level: 0.9},
+		epsilon:  1e-12,
+	},
+	{
+		name:     "Variance",
+		interval: func() (*Interval, error) { return Variance(sleep, 0.95) },
+		expected: Interval{Estimate: 3.200555555555556, Lower: 1.5142381, Upper: 10.6669797, Level: 0.95},
+		epsilon:  1e-6,
+	},
+	{
+		// With 10 values, the 95% interval of the median lies between the 2nd and 9th order statistics
+		name:     "Median",
+		interval: func() (*Interval, error) { return Median(sleep, 0.95) },
+		expected: Interval{Estimate: 0.35, Lower: -1.2, Upper: 3.4, Level: 1002.0 / 1024},
+		epsilon:  1e-12,
+	},
+	{
+		// Too small to reach the requested level: P(1 <= B <= 2) with B ~ Binomial(3, 0.9)
+		name:     "Percentile of a small sample",
+		interval: func() (*Interval, error) { return Percentile([]float64{3, 1, 2}, 90, 0.95) },
+		expected: Interval{Estimate: 3, Lower: 1, Upper: 3, Level: 0.271 - 0.001},
+		epsilon:  1e-12,
+	},
+}
+
+func TestIntervals(t *testing.T) {
+	for _, tt := range intervalTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.interval()
+			if err != nil {
+				t.Fatalf("unexpected error received: %v", err)
+			}
+
+			if math.Abs(got.Estimate-tt.expected.Estimate) > 1e-12 {
+				t.Errorf("expected estimate: %v, got:%v", tt.expected.Estimate, got.Estimate)
+			}
+
+			if math.Abs(got.Lower-tt.expected.Lower) > tt.epsilon || math.Abs(got.Upper-tt.expected.Upper) > tt.epsilon {
+				t.Errorf("expected interval: [%v, %v], got:[%v, %v]", tt.expected.Lower, tt.expected.Upper, got.Lower, got.Upper)
+			}
+
+			if math.Abs(got.Level-tt.expected.Level) > 1e-12 {
+				t.Errorf("expected level: %v, got:%v", tt.expected.Level, got.Level)
+			}
+		})
+	}
+}
+
+func TestClopperPearsonTails(t *testing.T) {
+	// Bounds are the proportions at which observing the successes has a tail probability of α/2
+	successes, trials, level := 7, 20, 0.95
+	ci, err := ProportionClopperPearson(successes, trials, level)
+	if err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+
+	lower, _ := distuv.NewBinomial(trials, ci.Lower)
+	if p := lower.Survival(successes - 1); math.Abs(p-0.025) > 1e-9 {
+		t.Errorf("expected upper tail at the lower bound: 0.025, got:%v", p)
+	}
+
+	upper, _ := distuv.NewBinomial(trials, ci.Upper)
+	if p := upper.CDF(successes); math.Abs(p-0.025) > 1e-9 {
+		t.Errorf("expected lower tail at the upper bound: 0.025, got:%v", p)
+	}
+}
+
+func TestIntervalErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"Invalid confidence level", second(MeanT(sleep, 1)), ErrInvalidConfidenceLevel},
+		{"Empty data", second(MeanT(nil, 0.95)), stats.ErrEmptyData},
+		{"Single value", second(Variance([]float64{1}, 0.95)), ErrNotEnoughData},
+		{"Null sigma", second(MeanZ(sleep, 0, 0.95)), stats.ErrNullStdDeviation},
+		{"Null trials", second(ProportionWilson(0, 0, 0.95)), ErrInvalidTrials},
+		{"Too many successes", second(ProportionClopperPearson(21, 20, 0.95)), ErrInvalidSuccesses},
+		{"Invalid percentile", second(Percentile(sleep, 120, 0.95)), stats.ErrInvalidPercentile},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err != tt.want {
+				t.Errorf("expected error: %v, got:%v", tt.want, tt.err)
+			}
+		})
+	}
+}
+
+func second[T any](_ T, err error) error {
+	return err
+}
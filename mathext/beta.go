@@ -0,0 +1,154 @@
+package mathext
+
+import "math"
+
+// Beta returns the beta function B(a, b) = Γ(a)Γ(b)/Γ(a+b)
+func Beta(a, b float64) float64 {
+	return math.Exp(Lbeta(a, b))
+}
+
+// Lbeta returns the natural logarithm of the beta function for positive arguments
+func Lbeta(a, b float64) float64 {
+	return Lgamma(a) + Lgamma(b) - Lgamma(a+b)
+}
+
+/*
+BetaIncReg returns the regularized incomplete beta function I_x(a, b).
+The continued fraction is evaluated on the side where it converges faster.
+It returns NaN if a or b are not greater than 0 or x is out of range (0 - 1)
+*/
+func BetaIncReg(a, b, x float64) float64 {
+	if !(a > 0) || !(b > 0) || !(x >= 0 && x <= 1) {
+		return math.NaN()
+	}
+
+	if x == 0 {
+		return 0
+	}
+	if x == 1 {
+		return 1
+	}
+
+	bt := math.Exp(a*math.Log(x) + b*math.Log1p(-x) - Lbeta(a, b))
+	if x < (a+1)/(a+b+2) {
+		return bt * betaContinuedFraction(a, b, x) / a
+	}
+	return 1 - bt*betaContinuedFraction(b, a, 1-x)/b
+}
+
+// Modified Lentz evaluation of the continued fraction of I_x(a, b)
+func betaContinuedFraction(a, b, x float64) float64 {
+	qab := a + b
+	qap := a + 1
+	qam := a - 1
+	c := 1.0
+	d := 1 - qab*x/qap
+	if math.Abs(d) < fpMin {
+		d = fpMin
+	}
+	d = 1 / d
+	h := d
+	for m := 1; m < maxIter; m++ {
+		fm := float64(m)
+		m2 := 2 * fm
+		aa := fm * (b - fm) * x / ((qam + m2) * (a + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < fpMin {
+			d = fpMin
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < fpMin {
+			c = fpMin
+		}
+		d = 1 / d
+		h *= d * c
+
+		aa = -(a + fm) * (qab + fm) * x / ((a + m2) * (qap + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < fpMin {
+			d = fpMin
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < fpMin {
+			c = fpMin
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < eps {
+			break
+		}
+	}
+
+	return h
+}
+
+/*
+BetaIncRegInv returns x such that I_x(a, b) = p.
+An initial guess is refined through Halley iterations.
+It returns NaN if a or b are not greater than 0 or p is out of range (0 - 1)
+*/
+func BetaIncRegInv(a, b, p float64) float64 {
+	if !(a > 0) || !(b > 0) || !(p >= 0 && p <= 1) {
+		return math.NaN()
+	}
+
+	if p == 0 {
+		return 0
+	}
+	if p == 1 {
+		return 1
+	}
+
+	var x float64
+	if a >= 1 && b >= 1 {
+		pp := p
+		if p >= 0.5 {
+			pp = 1 - p
+		}
+		t := math.Sqrt(-2 * math.Log(pp))
+		x = (2.30753+t*0.27061)/(1+t*(0.99229+t*0.04481)) - t
+		if p < 0.5 {
+			x = -x
+		}
+		al := (x*x - 3) / 6
+		h := 2 / (1/(2*a-1) + 1/(2*b-1))
+		w := x*math.Sqrt(al+h)/h - (1/(2*b-1)-1/(2*a-1))*(al+5.0/6-2/(3*h))
+		x = a / (a + b*math.Exp(2*w))
+	} else {
+		lna := math.Log(a / (a + b))
+		lnb := math.Log(b / (a + b))
+		t := math.Exp(a*lna) / a
+		u := math.Exp(b*lnb) / b
+		w := t + u
+		if p < t/w {
+			x = math.Pow(a*w*p, 1/a)
+		} else {
+			x = 1 - math.Pow(b*w*(1-p), 1/b)
+		}
+	}
+
+	afac := -Lbeta(a, b)
+	for i := 0; i < 100; i++ {
+		if x == 0 || x == 1 {
+			return x
+		}
+
+		err := BetaIncReg(a, b, x) - p
+		t := math.Exp((a-1)*math.Log(x) + (b-1)*math.Log1p(-x) + afac)
+		u := err / t
+		t = u / (1 - 0.5*math.Min(1, u*((a-1)/x-(b-1)/(1-x))))
+		x -= t
+		if x <= 0 {
+			x = 0.5 * (x + t)
+		}
+		if x >= 1 {
+			x = 0.5 * (x + t + 1)
+		}
+		if math.Abs(t) < 1e-14*x && i > 0 {
+			break
+		}
+	}
+
+	return x
+}
@@ -0,0 +1,63 @@
+package mathext
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBeta(t *testing.T) {
+	// B(2, 3) = 1!2!/4!
+	if b := Beta(2, 3); math.Abs(b-1.0/12) > 1e-15 {
+		t.Errorf("expected beta: %v, got:%v", 1.0/12, b)
+	}
+
+	// B(1/2, 1/2) = π
+	if lb := Lbeta(0.5, 0.5); math.Abs(lb-math.Log(math.Pi)) > 1e-14 {
+		t.Errorf("expected log beta: %v, got:%v", math.Log(math.Pi), lb)
+	}
+}
+
+// Reference values from closed forms: integer shapes reduce to binomial sums and
+// I_x(1/2, 1/2) to the arcsine distribution
+var betaIncTests = []specialTest{
+	{name: "Small integer shapes", args: []float64{2, 3, 0.4}, expected: 0.5248},
+	{name: "Large integer shapes", args: []float64{20, 30, 0.35}, expected: 0.23856016353438214},
+	{name: "Power function", args: []float64{5, 1, 0.7}, expected: math.Pow(0.7, 5)},
+	{name: "Arcsine", args: []float64{0.5, 0.5, 0.2}, expected: 2 / math.Pi * math.Asin(math.Sqrt(0.2))},
+	{name: "Upper side", args: []float64{1, 4, 0.9}, expected: 1 - math.Pow(0.1, 4)},
+}
+
+func TestBetaIncReg(t *testing.T) {
+	for _, tt := range betaIncTests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b, x := tt.args[0], tt.args[1], tt.args[2]
+			if p := BetaIncReg(a, b, x); math.Abs(p-tt.expected) > 1e-13 {
+				t.Errorf("expected I_x(a, b): %v, got:%v", tt.expected, p)
+			}
+
+			// Symmetry: I_x(a, b) = 1 - I_(1-x)(b, a)
+			if p := 1 - BetaIncReg(b, a, 1-x); math.Abs(p-tt.expected) > 1e-13 {
+				t.Errorf("expected 1 - I_(1-x)(b, a): %v, got:%v", tt.expected, p)
+			}
+
+			if inv := BetaIncRegInv(a, b, tt.expected); math.Abs(inv-x) > 1e-8 {
+				t.Errorf("expected inverse: %v, got:%v", x, inv)
+			}
+		})
+	}
+}
+
+func TestBetaIncRegInvArcsine(t *testing.T) {
+	expected := math.Pow(math.Sin(math.Pi*0.3/2), 2)
+	if x := BetaIncRegInv(0.5, 0.5, 0.3); math.Abs(x-expected) > 1e-12 {
+		t.Errorf("expected inverse: %v, got:%v", expected, x)
+	}
+}
+
+func TestBetaIncRegInvalid(t *testing.T) {
+	for _, v := range []float64{BetaIncReg(0, 1, 0.5), BetaIncReg(1, 1, 1.5), BetaIncRegInv(1, -1, 0.5), BetaIncRegInv(1, 1, -0.1)} {
+		if !math.IsNaN(v) {
+			t.Errorf("expected NaN for invalid arguments, got:%v", v)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+/*
+Package mathext provides special functions that the standard library math package
+lacks and that probability distributions and hypothesis tests are built on:
+the digamma function, the regularized incomplete gamma and beta functions and their inverses.
+
+The gamma function and the inverse error functions are already provided by the standard
+library as math.Gamma, math.Lgamma, math.Erfinv and math.Erfcinv.
+*/
+package mathext
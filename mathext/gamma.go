@@ -0,0 +1,205 @@
+package mathext
+
+import "math"
+
+const (
+	eps     = 1e-15
+	fpMin   = 1e-300
+	maxIter = 10000
+)
+
+/*
+Lgamma returns the natural logarithm of the absolute value of the gamma function.
+Unlike math.Lgamma, the sign of the gamma function is not returned, which is
+convenient for positive arguments
+*/
+func Lgamma(x float64) float64 {
+	lg, _ := math.Lgamma(x)
+	return lg
+}
+
+/*
+Digamma returns the logarithmic derivative of the gamma function, ψ(x) = Γ'(x)/Γ(x).
+It returns NaN for non positive integers
+*/
+func Digamma(x float64) float64 {
+	if math.IsNaN(x) || x <= 0 && x == math.Floor(x) {
+		return math.NaN()
+	}
+
+	if math.IsInf(x, 1) {
+		return x
+	}
+
+	if x < 0 {
+		return Digamma(1-x) - math.Pi/math.Tan(math.Pi*x)
+	}
+
+	// Recurrence up to a value where the asymptotic expansion is accurate
+	result := 0.0
+	for x < 10 {
+		result -= 1 / x
+		x++
+	}
+
+	f := 1 / (x * x)
+	t := f * (-1.0/12 + f*(1.0/120+f*(-1.0/252+f*(1.0/240+f*(-1.0/132+f*(691.0/32760+f*(-1.0/12)))))))
+	return result + math.Log(x) - 0.5/x + t
+}
+
+/*
+GammaIncReg returns the regularized lower incomplete gamma function P(a, x).
+It returns NaN if a is not greater than 0 or x is negative
+*/
+func GammaIncReg(a, x float64) float64 {
+	if !(a > 0) || !(x >= 0) {
+		return math.NaN()
+	}
+
+	if x == 0 {
+		return 0
+	}
+	if math.IsInf(x, 1) {
+		return 1
+	}
+
+	if x < a+1 {
+		return gammaSeries(a, x)
+	}
+	return 1 - gammaContinuedFraction(a, x)
+}
+
+/*
+GammaIncRegComp returns the regularized upper incomplete gamma function Q(a, x) = 1 - P(a, x).
+It returns NaN if a is not greater than 0 or x is negative
+*/
+func GammaIncRegComp(a, x float64) float64 {
+	if !(a > 0) || !(x >= 0) {
+		return math.NaN()
+	}
+
+	if x == 0 {
+		return 1
+	}
+	if math.IsInf(x, 1) {
+		return 0
+	}
+
+	if x < a+1 {
+		return 1 - gammaSeries(a, x)
+	}
+	return gammaContinuedFraction(a, x)
+}
+
+// Power series of P(a, x), which converges quickly when x < a+1
+func gammaSeries(a, x float64) float64 {
+	ap := a
+	sum := 1 / a
+	del := sum
+	for n := 0; n < maxIter; n++ {
+		ap++
+		del *= x / ap
+		sum += del
+		if math.Abs(del) < math.Abs(sum)*eps {
+			break
+		}
+	}
+
+	return sum * math.Exp(-x+a*math.Log(x)-Lgamma(a))
+}
+
+// Modified Lentz evaluation of the continued fraction of Q(a, x), which converges quickly when x >= a+1
+func gammaContinuedFraction(a, x float64) float64 {
+	b := x + 1 - a
+	c := 1 / fpMin
+	d := 1 / b
+	h := d
+	for i := 1; i < maxIter; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < fpMin {
+			d = fpMin
+		}
+		c = b + an/c
+		if math.Abs(c) < fpMin {
+			c = fpMin
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < eps {
+			break
+		}
+	}
+
+	return math.Exp(-x+a*math.Log(x)-Lgamma(a)) * h
+}
+
+/*
+GammaIncRegInv returns x such that P(a, x) = p.
+An initial guess is refined through Halley iterations.
+It returns NaN if a is not greater than 0 or p is out of range (0 - 1)
+*/
+func GammaIncRegInv(a, p float64) float64 {
+	if !(a > 0) || !(p >= 0 && p <= 1) {
+		return math.NaN()
+	}
+
+	if p == 0 {
+		return 0
+	}
+	if p == 1 {
+		return math.Inf(1)
+	}
+
+	a1 := a - 1
+	gln := Lgamma(a)
+	var x, lna1, afac float64
+	if a > 1 {
+		lna1 = math.Log(a1)
+		afac = math.Exp(a1*(lna1-1) - gln)
+		pp := p
+		if p >= 0.5 {
+			pp = 1 - p
+		}
+		t := math.Sqrt(-2 * math.Log(pp))
+		x = (2.30753+t*0.27061)/(1+t*(0.99229+t*0.04481)) - t
+		if p < 0.5 {
+			x = -x
+		}
+		x = math.Max(1e-3, a*math.Pow(1-1/(9*a)-x/(3*math.Sqrt(a)), 3))
+	} else {
+		t := 1 - a*(0.253+a*0.12)
+		if p < t {
+			x = math.Pow(p/t, 1/a)
+		} else {
+			x = 1 - math.Log(1-(p-t)/(1-t))
+		}
+	}
+
+	for i := 0; i < 100; i++ {
+		if x <= 0 {
+			return 0
+		}
+
+		err := GammaIncReg(a, x) - p
+		var t float64
+		if a > 1 {
+			t = afac * math.Exp(-(x-a1)+a1*(math.Log(x)-lna1))
+		} else {
+			t = math.Exp(-x + a1*math.Log(x) - gln)
+		}
+		u := err / t
+		t = u / (1 - 0.5*math.Min(1, u*((a-1)/x-1)))
+		x -= t
+		if x <= 0 {
+			x = 0.5 * (x + t)
+		}
+		if math.Abs(t) < 1e-14*x {
+			break
+		}
+	}
+
+	return x
+}
@@ -0,0 +1,98 @@
+package mathext
+
+import (
+	"math"
+	"testing"
+)
+
+type specialTest struct {
+	name     string
+	args     []float64
+	expected float64
+}
+
+func TestLgamma(t *testing.T) {
+	tests := []specialTest{
+		{name: "Half", args: []float64{0.5}, expected: 0.5 * math.Log(math.Pi)},
+		{name: "Integer", args: []float64{10}, expected: math.Log(362880)},
+		{name: "Negative", args: []float64{-0.5}, expected: math.Log(2 * math.Sqrt(math.Pi))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if lg := Lgamma(tt.args[0]); math.Abs(lg-tt.expected) > 1e-14 {
+				t.Errorf("expected lgamma: %v, got:%v", tt.expected, lg)
+			}
+		})
+	}
+}
+
+func TestDigamma(t *testing.T) {
+	tests := []specialTest{
+		{name: "One", args: []float64{1}, expected: -0.5772156649015329},
+		{name: "Half", args: []float64{0.5}, expected: -1.9635100260214235},
+		{name: "Quarter", args: []float64{0.25}, expected: -4.2274535333762655},
+		{name: "Ten", args: []float64{10}, expected: 2.251752589066721},
+		{name: "Negative half", args: []float64{-0.5}, expected: 0.03648997397857652},
+		{name: "Large", args: []float64{1e6}, expected: 13.81551005796419},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if dg := Digamma(tt.args[0]); math.Abs(dg-tt.expected) > 1e-13 {
+				t.Errorf("expected digamma: %v, got:%v", tt.expected, dg)
+			}
+		})
+	}
+
+	if dg := Digamma(-2); !math.IsNaN(dg) {
+		t.Errorf("expected NaN digamma at a pole, got:%v", dg)
+	}
+}
+
+// Reference values from closed forms: integer shapes reduce to Poisson sums and half
+// integer shapes to the error function
+var gammaIncTests = []specialTest{
+	{name: "Exponential", args: []float64{1, 2}, expected: 1 - math.Exp(-2)},
+	{name: "Half shape", args: []float64{0.5, 0.7}, expected: math.Erf(math.Sqrt(0.7))},
+	{name: "Integer shape", args: []float64{3, 2}, expected: 0.3233235838169365},
+	{name: "Half integer shape", args: []float64{2.5, 1.3}, expected: 0.2386347321549861},
+	{name: "Large shape below mean", args: []float64{100, 90}, expected: 0.15822098918643024},
+	{name: "Large shape above mean", args: []float64{100, 110}, expected: 1 - 0.15827867006008708},
+	{name: "Far tail", args: []float64{10, 30}, expected: 1 - 7.121750862815577e-06},
+}
+
+func TestGammaIncReg(t *testing.T) {
+	for _, tt := range gammaIncTests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, x := tt.args[0], tt.args[1]
+			if p := GammaIncReg(a, x); math.Abs(p-tt.expected) > 1e-13 {
+				t.Errorf("expected P(a, x): %v, got:%v", tt.expected, p)
+			}
+
+			if q := GammaIncRegComp(a, x); math.Abs(q-(1-tt.expected)) > 1e-13 {
+				t.Errorf("expected Q(a, x): %v, got:%v", 1-tt.expected, q)
+			}
+
+			if inv := GammaIncRegInv(a, tt.expected); math.Abs(inv-x) > 1e-8*x {
+				t.Errorf("expected inverse: %v, got:%v", x, inv)
+			}
+		})
+	}
+}
+
+func TestGammaIncRegFarTail(t *testing.T) {
+	// The complement must keep its relative precision where 1 - P(a, x) would underflow
+	expected := 7.121750862815577e-06
+	if q := GammaIncRegComp(10, 30); math.Abs(q-expected) > 1e-12*expected {
+		t.Errorf("expected Q(a, x): %v, got:%v", expected, q)
+	}
+}
+
+func TestGammaIncRegInvalid(t *testing.T) {
+	for _, v := range []float64{GammaIncReg(0, 1), GammaIncReg(1, -1), GammaIncRegComp(-1, 1), GammaIncRegInv(1, 1.5)} {
+		if !math.IsNaN(v) {
+			t.Errorf("expected NaN for invalid arguments, got:%v", v)
+		}
+	}
+}
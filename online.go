@@ -0,0 +1,166 @@
+package stats
+
+import "math"
+
+/*
+Accumulator computes descriptive statistics of a stream of values without storing them,
+using the numerically stable updates of Welford and Terriberry for the central moments.
+Values may be weighted, where weights act as frequencies, and all statistics are computed
+as it was a whole population. NaN values propagate to every statistic, so they should be
+filtered out before pushing them if needed. The zero value is an empty accumulator ready to use
+*/
+type Accumulator struct {
+	count  int
+	weight float64
+	sum    float64
+	mean   float64
+	m2     float64
+	m3     float64
+	m4     float64
+	min    float64
+	max    float64
+}
+
+// Returns a new empty Accumulator
+func NewAccumulator() *Accumulator {
+	return &Accumulator{}
+}
+
+// Adds a value to the accumulator
+func (a *Accumulator) Push(x float64) {
+	a.push(x, 1)
+}
+
+/*
+PushWeighted adds a value with a given weight to the accumulator, so pushing a value
+with weight 2 is equivalent to pushing it twice.
+It returns an error if the weight is not a finite value greater than 0
+*/
+func (a *Accumulator) PushWeighted(x, w float64) error {
+	if !(w > 0) || math.IsInf(w, 1) {
+		return ErrInvalidWeight
+	}
+
+	a.push(x, w)
+	return nil
+}
+
+// Adds a value of weight w, as a summary of a single value
+func (a *Accumulator) push(x, w float64) {
+	a.Merge(&Accumulator{count: 1, weight: w, sum: w * x, mean: x, min: x, max: x})
+}
+
+/*
+Merge combines another accumulator into this one, following the pairwise updates of Chan et al.
+extended to the third and fourth central moments by Pébay, so merging the accumulators of several
+partitions of the data gives the same statistics as pushing all of it into a single one.
+The other accumulator is not modified
+*/
+func (a *Accumulator) Merge(b *Accumulator) {
+	if b == nil || b.count == 0 {
+		return
+	}
+	if a.count == 0 {
+		*a = *b
+		return
+	}
+
+	na, nb := a.weight, b.weight
+	n := na + nb
+	delta := b.mean - a.mean
+	deltaN := delta / n
+	deltaN2 := deltaN * deltaN
+	term := delta * deltaN * na * nb
+
+	a.mean += deltaN * nb
+	a.m4 += b.m4 + term*deltaN2*(na*na-na*nb+nb*nb) +
+		6*deltaN2*(na*na*b.m2+nb*nb*a.m2) + 4*deltaN*(na*b.m3-nb*a.m3)
+	a.m3 += b.m3 + term*deltaN*(na-nb) + 3*deltaN*(na*b.m2-nb*a.m2)
+	a.m2 += b.m2 + term
+	a.weight = n
+	a.sum += b.sum
+	a.count += b.count
+	a.min = math.Min(a.min, b.min)
+	a.max = math.Max(a.max, b.max)
+}
+
+// Returns the number of values pushed into the accumulator
+func (a *Accumulator) Count() int {
+	return a.count
+}
+
+// Returns the sum of the weights of the values pushed into the accumulator
+func (a *Accumulator) Weight() float64 {
+	return a.weight
+}
+
+// Returns the (weighted) sum of the values
+func (a *Accumulator) Sum() float64 {
+	return a.sum
+}
+
+// Returns the (weighted) mean of the values and an error when the accumulator is empty
+func (a *Accumulator) Mean() (float64, error) {
+	if a.count == 0 {
+		return 0, ErrEmptyData
+	}
+	return a.mean, nil
+}
+
+// Returns the (weighted) variance of the values and an error when the accumulator is empty
+func (a *Accumulator) Variance() (float64, error) {
+	if a.count == 0 {
+		return 0, ErrEmptyData
+	}
+	return a.m2 / a.weight, nil
+}
+
+// Returns the (weighted) standard deviation of the values and an error when the accumulator is empty
+func (a *Accumulator) StandardDeviation() (float64, error) {
+	variance, err := a.Variance()
+	return math.Sqrt(variance), err
+}
+
+/*
+Skewness returns the (weighted) skewness of the values.
+It returns an error if the accumulator is empty or the standard deviation is null
+*/
+func (a *Accumulator) Skewness() (float64, error) {
+	if a.count == 0 {
+		return 0, ErrEmptyData
+	}
+	if a.m2 == 0 {
+		return 0, ErrNullStdDeviation
+	}
+	return math.Sqrt(a.weight) * a.m3 / math.Pow(a.m2, 1.5), nil
+}
+
+/*
+Kurtosis returns the (weighted) excess kurtosis of the values, as Kurtosis does.
+It returns an error if the accumulator is empty or the standard deviation is null
+*/
+func (a *Accumulator) Kurtosis() (float64, error) {
+	if a.count == 0 {
+		return 0, ErrEmptyData
+	}
+	if a.m2 == 0 {
+		return 0, ErrNullStdDeviation
+	}
+	return a.weight*a.m4/(a.m2*a.m2) - 3, nil
+}
+
+// Returns the minimum value and an error when the accumulator is empty
+func (a *Accumulator) Min() (float64, error) {
+	if a.count == 0 {
+		return 0, ErrEmptyData
+	}
+	return a.min, nil
+}
+
+// Returns the maximum value and an error when the accumulator is empty
+func (a *Accumulator) Max() (float64, error) {
+	if a.count == 0 {
+		return 0, ErrEmptyData
+	}
+	return a.max, nil
+}
@@ -0,0 +1,218 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+// Returns the statistics of an accumulator by name, failing on errors
+func accumulatorStats(t *testing.T, a *Accumulator) map[string]float64 {
+	t.Helper()
+	res := make(map[string]float64)
+	getters := map[string]func() (float64, error){
+		"mean": a.Mean, "variance": a.Variance, "std": a.StandardDeviation,
+		"skewness": a.Skewness, "kurtosis": a.Kurtosis, "min": a.Min, "max": a.Max,
+	}
+	for name, get := range getters {
+		v, err := get()
+		if err != nil {
+			t.Fatalf("unexpected error received computing %v: %v", name, err)
+		}
+		res[name] = v
+	}
+	return res
+}
+
+// Returns the statistics of data by name, failing on errors
+func batchStats(t *testing.T, data []float64) map[string]float64 {
+	t.Helper()
+	res := make(map[string]float64)
+	funcs := map[string]func([]float64) (float64, error){
+		"mean": Mean, "variance": Variance, "std": StandardDeviation,
+		"skewness": Skewness, "kurtosis": Kurtosis, "min": Min, "max": Max,
+	}
+	for name, f := range funcs {
+		v, err := f(data)
+		if err != nil {
+			t.Fatalf("unexpected error received computing %v: %v", name, err)
+		}
+		res[name] = v
+	}
+	return res
+}
+
+func TestAccumulator(t *testing.T) {
+	tests := []struct {
+		name string
+		data []float64
+	}{
+		{"Simple data", []float64{0.0, 2.0, -2.0, 1.5, 0.5, 0.0, -3.0, 4.5}},
+		{"Skewed data", []float64{1, 1, 1, 2, 2, 3, 5, 8, 13, 21}},
+		{"Two values", []float64{-1, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var acc Accumulator
+			for _, v := range tt.data {
+				acc.Push(v)
+			}
+
+			if acc.Count() != len(tt.data) || acc.Weight() != float64(len(tt.data)) {
+				t.Errorf("expected count and weight: %v, got:%v and %v", len(tt.data), acc.Count(), acc.Weight())
+			}
+
+			expected := batchStats(t, tt.data)
+			for name, v := range accumulatorStats(t, &acc) {
+				if math.Abs(v-expected[name]) > 1e-12 {
+					t.Errorf("expected %v: %v, got:%v", name, expected[name], v)
+				}
+			}
+		})
+	}
+}
+
+func TestAccumulatorWeighted(t *testing.T) {
+	values := []float64{2.5, -1, 4, 0.5}
+	weights := []float64{3, 1, 2, 4}
+
+	weighted := NewAccumulator()
+	var repeated []float64
+	for i, v := range values {
+		if err := weighted.PushWeighted(v, weights[i]); err != nil {
+			t.Fatalf("unexpected error received: %v", err)
+		}
+		for j := 0; j < int(weights[i]); j++ {
+			repeated = append(repeated, v)
+		}
+	}
+
+	if weighted.Count() != len(values) || weighted.Weight() != 10 {
+		t.Errorf("expected count %v and weight %v, got:%v and %v", len(values), 10, weighted.Count(), weighted.Weight())
+	}
+
+	// Weights act as frequencies
+	expected := batchStats(t, repeated)
+	for name, v := range accumulatorStats(t, weighted) {
+		if math.Abs(v-expected[name]) > 1e-12 {
+			t.Errorf("expected %v: %v, got:%v", name, expected[name], v)
+		}
+	}
+}
+
+func TestAccumulatorStability(t *testing.T) {
+	// A large offset makes the textbook sum of squares formula lose every significant digit
+	acc := NewAccumulator()
+	for _, v := range []float64{4, 7, 13, 16} {
+		acc.Push(1e9 + v)
+	}
+
+	variance, _ := acc.Variance()
+	if math.Abs(variance-22.5) > 1e-6 {
+		t.Errorf("expected variance: %v, got:%v", 22.5, variance)
+	}
+
+	skewness, _ := acc.Skewness()
+	if math.Abs(skewness) > 1e-6 {
+		t.Errorf("expected skewness: %v, got:%v", 0, skewness)
+	}
+}
+
+func TestAccumulatorMerge(t *testing.T) {
+	data := []float64{1, 1, 1, 2, 2, 3, 5, 8, 13, 21, -4.5, 0.25, 7}
+	expected := batchStats(t, data)
+
+	// Every way of splitting data in two contiguous partitions, including empty ones
+	for cut := 0; cut <= len(data); cut++ {
+		first, rest := NewAccumulator(), NewAccumulator()
+		for _, v := range data[:cut] {
+			first.Push(v)
+		}
+		for _, v := range data[cut:] {
+			rest.Push(v)
+		}
+		first.Merge(rest)
+
+		if first.Count() != len(data) || first.Sum() != Sum(data) {
+			t.Errorf("expected count %v and sum %v, got:%v and %v", len(data), Sum(data), first.Count(), first.Sum())
+		}
+
+		for name, v := range accumulatorStats(t, first) {
+			if math.Abs(v-expected[name]) > 1e-12 {
+				t.Errorf("expected %v splitting at %v: %v, got:%v", name, cut, expected[name], v)
+			}
+		}
+	}
+
+	// Merging weighted partitions of different sizes
+	weighted := NewAccumulator()
+	for i, v := range data {
+		weighted.PushWeighted(v, float64(i%3+1))
+	}
+	partitions := make([]*Accumulator, 3)
+	for i := range partitions {
+		partitions[i] = NewAccumulator()
+	}
+	for i, v := range data {
+		partitions[i*i%3].PushWeighted(v, float64(i%3+1))
+	}
+	merged := NewAccumulator()
+	for _, p := range partitions {
+		merged.Merge(p)
+	}
+	merged.Merge(nil)
+
+	got, want := accumulatorStats(t, merged), accumulatorStats(t, weighted)
+	for name, v := range got {
+		if math.Abs(v-want[name]) > 1e-12 {
+			t.Errorf("expected weighted %v: %v, got:%v", name, want[name], v)
+		}
+	}
+	if merged.Weight() != weighted.Weight() {
+		t.Errorf("expected weight: %v, got:%v", weighted.Weight(), merged.Weight())
+	}
+}
+
+func TestAccumulatorErrors(t *testing.T) {
+	var empty Accumulator
+	constant := NewAccumulator()
+	constant.Push(2)
+	constant.Push(2)
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"Empty mean", second(empty.Mean()), ErrEmptyData},
+		{"Empty variance", second(empty.Variance()), ErrEmptyData},
+		{"Empty standard deviation", second(empty.StandardDeviation()), ErrEmptyData},
+		{"Empty skewness", second(empty.Skewness()), ErrEmptyData},
+		{"Empty kurtosis", second(empty.Kurtosis()), ErrEmptyData},
+		{"Empty minimum", second(empty.Min()), ErrEmptyData},
+		{"Empty maximum", second(empty.Max()), ErrEmptyData},
+		{"Constant skewness", second(constant.Skewness()), ErrNullStdDeviation},
+		{"Constant kurtosis", second(constant.Kurtosis()), ErrNullStdDeviation},
+		{"Null weight", constant.PushWeighted(1, 0), ErrInvalidWeight},
+		{"Negative weight", constant.PushWeighted(1, -1), ErrInvalidWeight},
+		{"Infinite weight", constant.PushWeighted(1, math.Inf(1)), ErrInvalidWeight},
+		{"NaN weight", constant.PushWeighted(1, math.NaN()), ErrInvalidWeight},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err != tt.want {
+				t.Errorf("expected error: %v, got:%v", tt.want, tt.err)
+			}
+		})
+	}
+
+	if constant.Count() != 2 {
+		t.Errorf("expected invalid weights to be ignored, got count:%v", constant.Count())
+	}
+}
+
+// Returns the error of a function call, discarding its value
+func second[T any](_ T, err error) error {
+	return err
+}
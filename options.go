@@ -0,0 +1,138 @@
+package stats
+
+import "math"
+
+/*
+NaNPolicy defines how the functions handle NaN values in data:
+  - PropagateNaN: results are NaN when data contains NaN values, sorting places them last
+    and they are not equal to any other value
+  - OmitNaN: NaN values are removed before computing, so they are also absent from the returned slices
+  - ErrorOnNaN: functions return ErrNaNInData when data contains NaN values
+
+±Inf are regular values, ordered below and above any other one, which follow floating point arithmetic:
+for instance, the mean of data containing both of them is NaN
+*/
+type NaNPolicy int
+
+const (
+	PropagateNaN NaNPolicy = iota
+	OmitNaN
+	ErrorOnNaN
+)
+
+/*
+Estimator defines whether the moments describe data as a whole population or estimate
+those of the population data is sampled from:
+  - PopulationEstimator: variance with n denominator, Fisher-Pearson skewness g1 and excess kurtosis g2
+  - SampleEstimator: unbiased variance with n - 1 denominator (Bessel's correction), adjusted Fisher-Pearson
+    skewness G1 and sample excess kurtosis G2, which need at least 2, 3 and 4 values respectively.
+    The standard deviation is the square root of the unbiased variance
+*/
+type Estimator int
+
+const (
+	PopulationEstimator Estimator = iota
+	SampleEstimator
+)
+
+/*
+Options to set special features of the statistical functions:
+  - Summation: summation method of the aggregating functions, naive by default
+  - NaN: handling of NaN values, propagated by default
+  - Estimator: estimator of the variance, standard deviation, skewness and kurtosis, population by default
+  - Quantile: definition of the percentiles, quantiles and interquartile range, type 6 by default
+*/
+type Options struct {
+	Summation Summation
+	NaN       NaNPolicy
+	Estimator Estimator
+	Quantile  QuantileMethod
+}
+
+// Returns the summation method of some options, which may be nil
+func (o *Options) summation() Summation {
+	if o == nil {
+		return NaiveSummation
+	}
+	return o.Summation
+}
+
+// Returns the estimator of some options, which may be nil
+func (o *Options) estimator() Estimator {
+	if o == nil {
+		return PopulationEstimator
+	}
+	return o.Estimator
+}
+
+// Returns a copy of some options, which may be nil, with the population estimator
+func (o *Options) population() *Options {
+	res := &Options{}
+	if o != nil {
+		*res = *o
+	}
+	res.Estimator = PopulationEstimator
+	return res
+}
+
+// Returns the quantile method of some options, which may be nil
+func (o *Options) quantileMethod() QuantileMethod {
+	if o == nil {
+		return DefaultQuantile
+	}
+	return o.Quantile
+}
+
+// Returns the NaN policy of some options, which may be nil
+func (o *Options) nanPolicy() NaNPolicy {
+	if o == nil {
+		return PropagateNaN
+	}
+	return o.NaN
+}
+
+/*
+Returns the data to compute with according to the NaN policy, a copy without NaN values when they
+are omitted, and whether it contains NaN values, which only happens when they are propagated.
+It returns an error if data contains NaN values and they are not allowed
+*/
+func (o *Options) clean(data []float64) ([]float64, bool, error) {
+	count := 0
+	for _, v := range data {
+		if math.IsNaN(v) {
+			count++
+		}
+	}
+	if count == 0 {
+		return data, false, nil
+	}
+
+	switch o.nanPolicy() {
+	case OmitNaN:
+		res := make([]float64, 0, len(data)-count)
+		for _, v := range data {
+			if !math.IsNaN(v) {
+				res = append(res, v)
+			}
+		}
+		return res, false, nil
+	case ErrorOnNaN:
+		return nil, false, ErrNaNInData
+	default:
+		return data, true, nil
+	}
+}
+
+/*
+Returns the data to compute with according to the NaN policy as clean does, without looking for NaN values
+when they are propagated, for the functions whose arithmetic already propagates them.
+It returns an error if data contains NaN values and they are not allowed
+*/
+func (o *Options) filter(data []float64) ([]float64, error) {
+	if o.nanPolicy() == PropagateNaN {
+		return data, nil
+	}
+
+	data, _, err := o.clean(data)
+	return data, err
+}
@@ -0,0 +1,251 @@
+package stats
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+var (
+	withNaN    = []float64{3, math.NaN(), 1, 4, math.NaN(), 1, 5}
+	withoutNaN = []float64{3, 1, 4, 1, 5}
+)
+
+// Functions of data returning a single value, by name
+var nanFunctions = map[string]func([]float64, *Options) (float64, error){
+	"mean": MeanWith, "median": MedianWith, "mode": ModeWith, "variance": VarianceWith,
+	"std": StandardDeviationWith, "max": MaxWith, "min": MinWith, "range": RangeWith,
+	"sum": SumWith, "iqr": IQRWith, "skewness": SkewnessWith, "kurtosis": KurtosisWith,
+	"percentile": func(d []float64, o *Options) (float64, error) { return PercentileWith(d, 40, o) },
+	"quantile":   func(d []float64, o *Options) (float64, error) { return QuantileWith(d, 1, 3, o) },
+	"entropy":    func(d []float64, o *Options) (float64, error) { return EntropyWith(d, 2, o) },
+}
+
+func TestNaNPolicy(t *testing.T) {
+	for name, f := range nanFunctions {
+		t.Run(name, func(t *testing.T) {
+			propagated, err := f(withNaN, nil)
+			if err != nil || !math.IsNaN(propagated) {
+				t.Errorf("expected propagated NaN, got:%v (error %v)", propagated, err)
+			}
+
+			expected, _ := f(withoutNaN, nil)
+			omitted, err := f(withNaN, &Options{NaN: OmitNaN})
+			// Entropy adds up frequencies in map order, which may change the last digit
+			if err != nil || math.Abs(omitted-expected) > 1e-12 {
+				t.Errorf("expected value without NaN: %v, got:%v (error %v)", expected, omitted, err)
+			}
+
+			if _, err := f(withNaN, &Options{NaN: ErrorOnNaN}); err != ErrNaNInData {
+				t.Errorf("expected error: %v, got:%v", ErrNaNInData, err)
+			}
+
+			if v, err := f(withoutNaN, &Options{NaN: ErrorOnNaN}); err != nil || math.Abs(v-expected) > 1e-12 {
+				t.Errorf("expected value: %v, got:%v (error %v)", expected, v, err)
+			}
+
+			if _, err := f([]float64{math.NaN()}, &Options{NaN: OmitNaN}); err != ErrEmptyData && name != "sum" {
+				t.Errorf("expected error: %v, got:%v", ErrEmptyData, err)
+			}
+		})
+	}
+
+	// The functions without options propagate NaN values
+	max, _ := Max(withNaN)
+	min, _ := Min([]float64{1, 2, math.NaN()})
+	if !math.IsNaN(max) || !math.IsNaN(min) {
+		t.Errorf("expected NaN maximum and minimum, got:%v and %v", max, min)
+	}
+}
+
+func TestNaNPolicySummation(t *testing.T) {
+	// Propagated NaN values are not looked for beforehand, the aggregations add them up
+	for _, s := range []Summation{NaiveSummation, NeumaierSummation, PairwiseSummation} {
+		for _, name := range []string{"mean", "sum", "variance", "skewness"} {
+			if v, err := nanFunctions[name](withNaN, &Options{Summation: s}); err != nil || !math.IsNaN(v) {
+				t.Errorf("expected propagated NaN %v with summation %v, got:%v (error %v)", name, s, v, err)
+			}
+		}
+	}
+}
+
+func TestNaNPolicySlices(t *testing.T) {
+	omit := &Options{NaN: OmitNaN}
+	fail := &Options{NaN: ErrorOnNaN}
+
+	sorted := Sort(withNaN)
+	if !reflect.DeepEqual(sorted[:5], []float64{1, 1, 3, 4, 5}) || !math.IsNaN(sorted[5]) || !math.IsNaN(sorted[6]) {
+		t.Errorf("expected NaN values sorted last, got:%v", sorted)
+	}
+
+	reversed := ReverseSort(withNaN)
+	if !math.IsNaN(reversed[0]) || !reflect.DeepEqual(reversed[2:], []float64{5, 4, 3, 1, 1}) {
+		t.Errorf("expected NaN values sorted first in reverse, got:%v", reversed)
+	}
+
+	if sorted, _ := SortWith(withNaN, omit); !reflect.DeepEqual(sorted, []float64{1, 1, 3, 4, 5}) {
+		t.Errorf("expected sorted data without NaN, got:%v", sorted)
+	}
+
+	if scaled, _ := ScaleWith(withNaN, 2, omit); !reflect.DeepEqual(scaled, []float64{6, 2, 8, 2, 10}) {
+		t.Errorf("expected scaled data without NaN, got:%v", scaled)
+	}
+
+	normalized, _ := NormalizeWith(withNaN, omit)
+	expected, _ := Normalize(withoutNaN)
+	if !reflect.DeepEqual(normalized, expected) {
+		t.Errorf("expected normalized data without NaN: %v, got:%v", expected, normalized)
+	}
+
+	freq, _ := Frequency(withNaN, 0)
+	for v, count := range freq {
+		if math.IsNaN(v) && count != 2 {
+			t.Errorf("expected NaN values counted together, got:%v", freq)
+		}
+	}
+	if len(freq) != 5 {
+		t.Errorf("expected 5 different values, got:%v", freq)
+	}
+
+	if Equals([]float64{1, math.NaN()}, []float64{1, 2}, 1) {
+		t.Errorf("expected NaN values not to be equal to any value")
+	}
+	if equal, _ := EqualsWith([]float64{1, math.NaN(), 2}, []float64{1, 2}, 0, omit); !equal {
+		t.Errorf("expected data to be equal omitting NaN values")
+	}
+
+	if intersection := Intersection(withNaN, []float64{math.NaN(), 4}, 0); !reflect.DeepEqual(intersection, []float64{4}) {
+		t.Errorf("expected NaN values not to be common, got:%v", intersection)
+	}
+
+	union := Union(withNaN, []float64{math.NaN(), 9}, 0)
+	nans := 0
+	for _, v := range union {
+		if math.IsNaN(v) {
+			nans++
+		}
+	}
+	if len(union) != 6 || nans != 1 {
+		t.Errorf("expected union with a single NaN, got:%v", union)
+	}
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"Sort", second(SortWith(withNaN, fail))},
+		{"Reverse sort", second(ReverseSortWith(withNaN, fail))},
+		{"Normalize", second(NormalizeWith(withNaN, fail))},
+		{"Scale", second(ScaleWith(withNaN, 2, fail))},
+		{"Frequency", second(FrequencyWith(withNaN, 0, fail))},
+		{"Equals", second(EqualsWith(withoutNaN, withNaN, 0, fail))},
+		{"Intersection", second(IntersectionWith(withNaN, withoutNaN, 0, fail))},
+		{"Union", second(UnionWith(withoutNaN, withNaN, 0, fail))},
+		{"Parallel", second(ParallelMean(withNaN, &ParallelOptions{Options: *fail}))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err != ErrNaNInData {
+				t.Errorf("expected error: %v, got:%v", ErrNaNInData, tt.err)
+			}
+		})
+	}
+}
+
+func TestNaNPolicyParallel(t *testing.T) {
+	data := make([]float64, 1000)
+	for i := range data {
+		data[i] = float64(i % 7)
+	}
+	data[500] = math.NaN()
+
+	for name, f := range map[string]func([]float64, *ParallelOptions) (float64, error){
+		"sum": ParallelSum, "mean": ParallelMean, "variance": ParallelVariance, "max": ParallelMax, "min": ParallelMin,
+	} {
+		if v, err := f(data, &ParallelOptions{ChunkSize: 100}); err != nil || !math.IsNaN(v) {
+			t.Errorf("expected propagated NaN %v, got:%v (error %v)", name, v, err)
+		}
+
+		expected, _ := nanFunctions[name](data, &Options{NaN: OmitNaN})
+		omitted, err := f(data, &ParallelOptions{ChunkSize: 100, Options: Options{NaN: OmitNaN}})
+		if err != nil || math.Abs(omitted-expected) > 1e-12 {
+			t.Errorf("expected %v without NaN: %v, got:%v (error %v)", name, expected, omitted, err)
+		}
+	}
+
+	if _, err := ParallelMean([]float64{math.NaN()}, &ParallelOptions{Options: Options{NaN: OmitNaN}}); err != ErrEmptyData {
+		t.Errorf("expected error: %v, got:%v", ErrEmptyData, err)
+	}
+}
+
+func TestInfinities(t *testing.T) {
+	data := []float64{2, math.Inf(1), -1, math.Inf(-1)}
+
+	sorted := Sort(data)
+	if !reflect.DeepEqual(sorted, []float64{math.Inf(-1), -1, 2, math.Inf(1)}) {
+		t.Errorf("expected infinities sorted at both ends, got:%v", sorted)
+	}
+
+	max, _ := Max(data)
+	median, _ := Median(data)
+	if !math.IsInf(max, 1) || median != 0.5 {
+		t.Errorf("expected maximum +Inf and median 0.5, got:%v and %v", max, median)
+	}
+
+	for _, s := range []Summation{NaiveSummation, NeumaierSummation, PairwiseSummation} {
+		opts := &Options{Summation: s}
+		if sum, _ := SumWith(data, opts); !math.IsNaN(sum) {
+			t.Errorf("expected NaN sum of both infinities with summation %v, got:%v", s, sum)
+		}
+		if sum, _ := SumWith(data[:3], opts); !math.IsInf(sum, 1) {
+			t.Errorf("expected +Inf sum with summation %v, got:%v", s, sum)
+		}
+	}
+}
+
+func TestEstimator(t *testing.T) {
+	data := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	sample := &Options{Estimator: SampleEstimator}
+
+	tests := []struct {
+		name       string
+		f          func([]float64, *Options) (float64, error)
+		population float64
+		sample     float64
+		minimum    int
+	}{
+		{"variance", VarianceWith, 4, 32.0 / 7, 2},
+		{"std", StandardDeviationWith, 2, math.Sqrt(32.0 / 7), 2},
+		{"skewness", SkewnessWith, 0.65625, 0.8184875533567997, 3},
+		{"kurtosis", KurtosisWith, -0.21875, 0.940625, 4},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if v, err := test.f(data, nil); err != nil || math.Abs(v-test.population) > 1e-12 {
+				t.Errorf("expected population estimate: %v, got:%v (error %v)", test.population, v, err)
+			}
+
+			if v, err := test.f(data, sample); err != nil || math.Abs(v-test.sample) > 1e-12 {
+				t.Errorf("expected sample estimate: %v, got:%v (error %v)", test.sample, v, err)
+			}
+
+			if _, err := test.f(data[:test.minimum-1], sample); err != ErrNotEnoughData {
+				t.Errorf("expected error: %v, got:%v", ErrNotEnoughData, err)
+			}
+
+			if _, err := test.f(data[:test.minimum], sample); err != nil {
+				t.Errorf("unexpected error received: %v", err)
+			}
+		})
+	}
+
+	parallel, err := ParallelVariance(data, &ParallelOptions{ChunkSize: 3, Options: *sample})
+	if err != nil || math.Abs(parallel-32.0/7) > 1e-12 {
+		t.Errorf("expected parallel sample variance: %v, got:%v (error %v)", 32.0/7, parallel, err)
+	}
+	if _, err := ParallelVariance(data[:1], &ParallelOptions{Options: *sample}); err != ErrNotEnoughData {
+		t.Errorf("expected error: %v, got:%v", ErrNotEnoughData, err)
+	}
+}
@@ -0,0 +1,212 @@
+package stats
+
+import (
+	"math"
+	"runtime"
+	"sync"
+)
+
+const defaultChunkSize = 1 << 16
+
+/*
+ParallelOptions to set special features of the parallel computations:
+  - Workers: number of goroutines processing the chunks, the number of CPUs by default
+  - ChunkSize: number of values of each chunk, 65536 by default
+  - Options: options of the computations within and across chunks, such as the summation method
+
+Data is split into chunks of ChunkSize values whose partial results are merged in order,
+so results depend on ChunkSize but not on Workers or on the goroutines scheduling
+*/
+type ParallelOptions struct {
+	Workers   int
+	ChunkSize int
+	Options
+}
+
+/*
+Returns the summary of data computed in parallel: each chunk is summarized with two passes
+and the summaries are merged in order.
+It returns an error if data is empty or contains NaN values when they are not allowed
+*/
+func parallelSummary(data []float64, opts *ParallelOptions) (*Accumulator, error) {
+	if len(data) == 0 {
+		return nil, ErrEmptyData
+	}
+	if opts == nil {
+		opts = &ParallelOptions{}
+	}
+
+	size := opts.ChunkSize
+	if size <= 0 {
+		size = defaultChunkSize
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	chunks := (len(data) + size - 1) / size
+	workers = min(workers, chunks)
+	partials := make([]Accumulator, chunks)
+	errs := make([]error, chunks)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < chunks; i += workers {
+				partials[i], errs[i] = summarize(data[i*size:min((i+1)*size, len(data))], &opts.Options)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	acc := NewAccumulator()
+	for i := range partials {
+		acc.Merge(&partials[i])
+	}
+	acc.sum = opts.Summation.Sum(chunks, func(i int) float64 {
+		return partials[i].sum
+	})
+	if acc.count == 0 {
+		return nil, ErrEmptyData
+	}
+	return acc, nil
+}
+
+/*
+Returns the summary of a chunk of data, computing its central moments around its mean.
+It returns an error if the chunk contains NaN values when they are not allowed
+*/
+func summarize(data []float64, opts *Options) (Accumulator, error) {
+	data, err := opts.filter(data)
+	if err != nil || len(data) == 0 {
+		return Accumulator{}, err
+	}
+
+	n := len(data)
+	s := opts.summation()
+	a := Accumulator{count: n, weight: float64(n), min: data[0], max: data[0]}
+	nan := false
+	for _, v := range data {
+		if math.IsNaN(v) {
+			nan = true
+		}
+		if v < a.min {
+			a.min = v
+		}
+		if v > a.max {
+			a.max = v
+		}
+	}
+	a.sum = sum(data, s)
+	a.mean = a.sum / a.weight
+	if nan {
+		a.min, a.max = math.NaN(), math.NaN()
+	}
+
+	if s == NaiveSummation {
+		for _, v := range data {
+			d := v - a.mean
+			d2 := d * d
+			a.m2 += d2
+			a.m3 += d2 * d
+			a.m4 += d2 * d2
+		}
+		return a, nil
+	}
+
+	deviation := func(i int) float64 {
+		return data[i] - a.mean
+	}
+	a.m2 = s.Sum(n, func(i int) float64 {
+		d := deviation(i)
+		return d * d
+	})
+	a.m3 = s.Sum(n, func(i int) float64 {
+		d := deviation(i)
+		return d * d * d
+	})
+	a.m4 = s.Sum(n, func(i int) float64 {
+		d := deviation(i)
+		return d * d * d * d
+	})
+	return a, nil
+}
+
+/*
+ParallelSum computes the sum of all the elements of a []float64 data input splitting it between goroutines.
+It returns 0 if the data is empty and an error if it contains NaN values when they are not allowed
+*/
+func ParallelSum(data []float64, opts *ParallelOptions) (float64, error) {
+	acc, err := parallelSummary(data, opts)
+	if err == ErrEmptyData {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return acc.Sum(), nil
+}
+
+/*
+ParallelMean computes the mean value of a []float64 data input splitting it between goroutines.
+It returns an error if the data is empty
+*/
+func ParallelMean(data []float64, opts *ParallelOptions) (float64, error) {
+	acc, err := parallelSummary(data, opts)
+	if err != nil {
+		return 0, err
+	}
+	return acc.Mean()
+}
+
+/*
+ParallelVariance computes the variance value of a []float64 data input splitting it between goroutines.
+It returns an error if the data is empty or has a single value for the sample estimator
+*/
+func ParallelVariance(data []float64, opts *ParallelOptions) (float64, error) {
+	acc, err := parallelSummary(data, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	if opts != nil && opts.Estimator == SampleEstimator {
+		if acc.count < 2 {
+			return 0, ErrNotEnoughData
+		}
+		return acc.m2 / (acc.weight - 1), nil
+	}
+	return acc.Variance()
+}
+
+/*
+ParallelMax returns the maximum value of a []float64 data input splitting it between goroutines.
+It returns an error if the data is empty
+*/
+func ParallelMax(data []float64, opts *ParallelOptions) (float64, error) {
+	acc, err := parallelSummary(data, opts)
+	if err != nil {
+		return 0, err
+	}
+	return acc.Max()
+}
+
+/*
+ParallelMin returns the minimum value of a []float64 data input splitting it between goroutines.
+It returns an error if the data is empty
+*/
+func ParallelMin(data []float64, opts *ParallelOptions) (float64, error) {
+	acc, err := parallelSummary(data, opts)
+	if err != nil {
+		return 0, err
+	}
+	return acc.Min()
+}
@@ -0,0 +1,82 @@
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestParallel(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	data := make([]float64, 100003)
+	for i := range data {
+		data[i] = 1e6 + r.NormFloat64()*3
+	}
+
+	mean, _ := Mean(data)
+	variance, _ := Variance(data)
+	max, _ := Max(data)
+	min, _ := Min(data)
+	expected := map[string]float64{"sum": Sum(data), "mean": mean, "variance": variance, "max": max, "min": min}
+
+	results := func(opts *ParallelOptions) map[string]float64 {
+		res := make(map[string]float64)
+		for name, f := range map[string]func([]float64, *ParallelOptions) (float64, error){
+			"sum": ParallelSum, "mean": ParallelMean, "variance": ParallelVariance, "max": ParallelMax, "min": ParallelMin,
+		} {
+			v, err := f(data, opts)
+			if err != nil {
+				t.Fatalf("unexpected error received computing %v: %v", name, err)
+			}
+			res[name] = v
+		}
+		return res
+	}
+
+	tests := []struct {
+		name string
+		opts *ParallelOptions
+	}{
+		{"Default options", nil},
+		{"Single worker", &ParallelOptions{Workers: 1}},
+		{"Small chunks", &ParallelOptions{Workers: 4, ChunkSize: 1000}},
+		{"More workers than chunks", &ParallelOptions{Workers: 64, ChunkSize: 50000}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, v := range results(tt.opts) {
+				if math.Abs(v-expected[name]) > 1e-9*math.Abs(expected[name]) {
+					t.Errorf("expected %v: %v, got:%v", name, expected[name], v)
+				}
+			}
+		})
+	}
+
+	// Results only depend on the chunk size
+	reference := results(&ParallelOptions{Workers: 1, ChunkSize: 777})
+	for _, workers := range []int{2, 3, 8, 200} {
+		for i := 0; i < 5; i++ {
+			got := results(&ParallelOptions{Workers: workers, ChunkSize: 777})
+			for name, v := range got {
+				if v != reference[name] {
+					t.Errorf("expected %v with %v workers: %v, got:%v", name, workers, reference[name], v)
+				}
+			}
+		}
+	}
+}
+
+func TestParallelEmptyData(t *testing.T) {
+	if sum, err := ParallelSum(nil, nil); sum != 0 || err != nil {
+		t.Errorf("expected sum: %v, got:%v (error %v)", 0, sum, err)
+	}
+
+	for name, f := range map[string]func([]float64, *ParallelOptions) (float64, error){
+		"mean": ParallelMean, "variance": ParallelVariance, "max": ParallelMax, "min": ParallelMin,
+	} {
+		if err := second(f([]float64{}, nil)); err != ErrEmptyData {
+			t.Errorf("expected error computing %v: %v, got:%v", name, ErrEmptyData, err)
+		}
+	}
+}
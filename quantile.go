@@ -0,0 +1,189 @@
+package stats
+
+import (
+	"math"
+	"math/bits"
+	"slices"
+)
+
+/*
+QuantileMethod defines how quantiles are estimated from data, following the nine definitions
+of Hyndman and Fan, which match the type argument of R's quantile function:
+  - QuantileType1: inverse of the empirical distribution function (NumPy's inverted_cdf)
+  - QuantileType2: like type 1 but averaging at discontinuities (NumPy's averaged_inverted_cdf, SAS default)
+  - QuantileType3: nearest even order statistic (NumPy's closest_observation)
+  - QuantileType4: linear interpolation of the empirical distribution function (NumPy's interpolated_inverted_cdf)
+  - QuantileType5: piecewise linear function with knots at the middle of the steps (NumPy's hazen)
+  - QuantileType6: linear interpolation of the expectations of the order statistics for the uniform distribution,
+    the (n+1)p position used by default (NumPy's weibull, Excel's PERCENTILE.EXC)
+  - QuantileType7: linear interpolation of the modes of the order statistics, between the minimum and maximum
+    (R and NumPy default linear, Excel's PERCENTILE.INC)
+  - QuantileType8: approximately median-unbiased whatever the distribution (NumPy's median_unbiased)
+  - QuantileType9: approximately unbiased for normally distributed data (NumPy's normal_unbiased)
+
+Types 1 to 3 return values of data while the rest interpolate between consecutive ones.
+Positions out of data are clamped to its minimum and maximum
+*/
+type QuantileMethod int
+
+const (
+	DefaultQuantile QuantileMethod = iota
+	QuantileType1
+	QuantileType2
+	QuantileType3
+	QuantileType4
+	QuantileType5
+	QuantileType6
+	QuantileType7
+	QuantileType8
+	QuantileType9
+)
+
+/*
+Returns the position of a quantile in sorted data of length n, which must not be 0, given a probability
+between 0 and 1: the quantile is the k-th value of data, counting from 0, plus frac times the difference
+between the next one and it, where frac is in [0, 1)
+*/
+func (m QuantileMethod) position(n int, p float64) (k int, frac float64) {
+	fn := float64(n)
+
+	var h float64
+	switch m {
+	case QuantileType1, QuantileType2, QuantileType3, QuantileType4:
+		h = fn * p
+	case QuantileType5:
+		h = fn*p + 0.5
+	case QuantileType7:
+		h = (fn-1)*p + 1
+	case QuantileType8:
+		h = (fn+1.0/3)*p + 1.0/3
+	case QuantileType9:
+		h = (fn+0.25)*p + 3.0/8
+	default:
+		h = (fn + 1) * p
+	}
+
+	// Positions a few rounding errors away from an order statistic are taken as it,
+	// so that for instance the 30th percentile of 10 values is exactly the third one
+	j := math.Floor(h)
+	if r := math.Round(h); math.Abs(h-r) <= 4*epsilon*math.Max(1, h) {
+		h, j = r, r
+	}
+
+	switch m {
+	case QuantileType1:
+		return orderStatistic(n, math.Ceil(h)), 0
+	case QuantileType2:
+		if h == j && j >= 1 && j < fn {
+			// Both order statistics around the discontinuity are averaged
+			return int(j) - 1, 0.5
+		}
+		return orderStatistic(n, math.Ceil(h)), 0
+	case QuantileType3:
+		return orderStatistic(n, math.RoundToEven(h)), 0
+	}
+
+	if h <= 1 {
+		return 0, 0
+	}
+	if h >= fn {
+		return n - 1, 0
+	}
+	return int(j) - 1, h - j
+}
+
+// Machine epsilon of float64 values
+const epsilon = 0x1p-52
+
+// Returns the index of the k-th order statistic of data of length n, counting k from 1 and clamped to the data
+func orderStatistic(n int, k float64) int {
+	if k < 1 {
+		return 0
+	}
+	if k > float64(n) {
+		return n - 1
+	}
+	return int(k) - 1
+}
+
+// Returns the quantile of sorted data, which must not be empty, given a probability between 0 and 1
+func (m QuantileMethod) quantile(sorted []float64, p float64) float64 {
+	k, frac := m.position(len(sorted), p)
+	if frac == 0 {
+		return sorted[k]
+	}
+	return sorted[k] + (sorted[k+1]-sorted[k])*frac
+}
+
+/*
+Returns the quantile of data, which must not be empty nor contain NaN values, given a probability
+between 0 and 1. It selects the order statistics it needs in linear time instead of sorting data,
+which is reordered
+*/
+func (m QuantileMethod) selectQuantile(data []float64, p float64) float64 {
+	k, frac := m.position(len(data), p)
+	lower := selectK(data, k)
+	if frac == 0 {
+		return lower
+	}
+
+	// Values after the k-th one are not lower than it, so the next order statistic is their minimum
+	upper := slices.Min(data[k+1:])
+	return lower + (upper-lower)*frac
+}
+
+/*
+Returns the k-th smallest value of data, counting from 0, reordering it so that no value before
+the k-th one is greater than it and no value after it is lower. Data must not contain NaN values.
+It is an introselect: quickselect with a median of three pivot and a three-way partition,
+which falls back to sorting when partitions do not shrink fast enough, to keep O(n log n) worst case
+*/
+func selectK(data []float64, k int) float64 {
+	lo, hi := 0, len(data)-1
+	budget := 2 * bits.Len(uint(len(data)))
+	for lo < hi {
+		if budget == 0 {
+			slices.Sort(data[lo : hi+1])
+			return data[k]
+		}
+		budget--
+
+		pivot := medianOfThree(data[lo], data[lo+(hi-lo)/2], data[hi])
+		// Values in [lo, lt) are lower than the pivot, in [lt, gt] equal to it and in (gt, hi] greater
+		lt, i, gt := lo, lo, hi
+		for i <= gt {
+			switch {
+			case data[i] < pivot:
+				data[lt], data[i] = data[i], data[lt]
+				lt++
+				i++
+			case data[i] > pivot:
+				data[i], data[gt] = data[gt], data[i]
+				gt--
+			default:
+				i++
+			}
+		}
+
+		switch {
+		case k < lt:
+			hi = lt - 1
+		case k > gt:
+			lo = gt + 1
+		default:
+			return data[k]
+		}
+	}
+	return data[k]
+}
+
+// Returns the median of three values
+func medianOfThree(a, b, c float64) float64 {
+	if a > b {
+		a, b = b, a
+	}
+	if b > c {
+		b = c
+	}
+	return max(a, b)
+}
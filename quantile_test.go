@@ -0,0 +1,186 @@
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestQuantileMethods(t *testing.T) {
+	data := []float64{7, 1, 3, 9, 4, 12, 6, 2, 10, 5}
+	percentages := []float64{0, 10, 25, 30, 50, 75, 90, 100}
+
+	// Expected values given by R's quantile function with each type
+	tests := []struct {
+		method   QuantileMethod
+		expected []float64
+	}{
+		{DefaultQuantile, []float64{1, 1.1, 2.75, 3.3, 5.5, 9.25, 11.8, 12}},
+		{QuantileType1, []float64{1, 1, 3, 3, 5, 9, 10, 12}},
+		{QuantileType2, []float64{1, 1.5, 3, 3.5, 5.5, 9, 11, 12}},
+		{QuantileType3, []float64{1, 1, 2, 3, 5, 9, 10, 12}},
+		{QuantileType4, []float64{1, 1, 2.5, 3, 5, 8, 10, 12}},
+		{QuantileType5, []float64{1, 1.5, 3, 3.5, 5.5, 9, 11, 12}},
+		{QuantileType6, []float64{1, 1.1, 2.75, 3.3, 5.5, 9.25, 11.8, 12}},
+		{QuantileType7, []float64{1, 1.9, 3.25, 3.7, 5.5, 8.5, 10.2, 12}},
+		{QuantileType8, []float64{1, 1.3666666666666667, 2.9166666666666665, 3.433333333333333, 5.5, 9.083333333333334, 11.266666666666667, 12}},
+		{QuantileType9, []float64{1, 1.4, 2.9375, 3.45, 5.5, 9.0625, 11.2, 12}},
+	}
+
+	for _, test := range tests {
+		opts := &Options{Quantile: test.method}
+		for i, p := range percentages {
+			res, err := PercentileWith(data, p, opts)
+			if err != nil || math.Abs(res-test.expected[i]) > 1e-12 {
+				t.Errorf("expected type %d percentile %v: %v, got:%v (error %v)", test.method, p, test.expected[i], res, err)
+			}
+		}
+
+		q1, _ := QuantileWith(data, 1, 4, opts)
+		q3, _ := QuantileWith(data, 3, 4, opts)
+		if math.Abs(q1-test.expected[2]) > 1e-12 || math.Abs(q3-test.expected[5]) > 1e-12 {
+			t.Errorf("expected type %d quartiles: %v and %v, got:%v and %v", test.method, test.expected[2], test.expected[5], q1, q3)
+		}
+
+		iqr, err := IQRWith(data, opts)
+		if err != nil || math.Abs(iqr-(test.expected[5]-test.expected[2])) > 1e-12 {
+			t.Errorf("expected type %d interquartile range: %v, got:%v (error %v)", test.method, test.expected[5]-test.expected[2], iqr, err)
+		}
+	}
+}
+
+func TestQuantileMethodsOddLength(t *testing.T) {
+	data := []float64{3, 1, 4, 1, 5, 9, 2}
+
+	// Expected quartiles and median given by R's quantile function with each type
+	tests := []struct {
+		method   QuantileMethod
+		expected [3]float64
+	}{
+		{QuantileType1, [3]float64{1, 3, 5}},
+		{QuantileType2, [3]float64{1, 3, 5}},
+		{QuantileType3, [3]float64{1, 3, 4}},
+		{QuantileType4, [3]float64{1, 2.5, 4.25}},
+		{QuantileType5, [3]float64{1.25, 3, 4.75}},
+		{QuantileType6, [3]float64{1, 3, 5}},
+		{QuantileType7, [3]float64{1.5, 3, 4.5}},
+		{QuantileType8, [3]float64{1.1666666666666667, 3, 4.833333333333333}},
+		{QuantileType9, [3]float64{1.1875, 3, 4.8125}},
+	}
+
+	for _, test := range tests {
+		for i := range test.expected {
+			res, err := QuantileWith(data, float64(i+1), 4, &Options{Quantile: test.method})
+			if err != nil || math.Abs(res-test.expected[i]) > 1e-12 {
+				t.Errorf("expected type %d quartile %d: %v, got:%v (error %v)", test.method, i+1, test.expected[i], res, err)
+			}
+		}
+	}
+}
+
+func TestQuantileClamping(t *testing.T) {
+	data := []float64{7, 1, 3, 9, 4, 12, 6, 2, 10, 5}
+
+	// Positions are clamped with the data length, not with the number of quantiles
+	res, err := Quantile(data, 3, 4)
+	if err != nil || res != 9.25 {
+		t.Errorf("expected third quartile: %v, got:%v (error %v)", 9.25, res, err)
+	}
+
+	if _, err := Quantile(data, 0, 0); err != ErrInvalideQuantile {
+		t.Errorf("expected error: %v, got:%v", ErrInvalideQuantile, err)
+	}
+
+	// Single values are every quantile of their data
+	for method := QuantileType1; method <= QuantileType9; method++ {
+		for _, p := range []float64{0, 30, 100} {
+			if res, _ := PercentileWith([]float64{4}, p, &Options{Quantile: method}); res != 4 {
+				t.Errorf("expected type %d percentile %v of a single value: %v, got:%v", method, p, 4, res)
+			}
+		}
+	}
+}
+
+func TestPercentiles(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	data := make([]float64, 1001)
+	for i := range data {
+		// Few distinct values, so that there are many repeated ones
+		data[i] = float64(r.Intn(50)) - 10
+	}
+	original := append([]float64(nil), data...)
+	ps := []float64{0, 0.1, 25, 50, 90, 95, 99, 99.9, 100}
+
+	for method := DefaultQuantile; method <= QuantileType9; method++ {
+		opts := &Options{Quantile: method}
+		res, err := PercentilesWith(data, ps, opts)
+		if err != nil {
+			t.Fatalf("unexpected error received: %v", err)
+		}
+
+		sorted := Sort(data)
+		for i, p := range ps {
+			single, _ := PercentileWith(data, p, opts)
+			expected := method.quantile(sorted, p/100)
+			if res[i] != expected || single != expected {
+				t.Errorf("expected type %d percentile %v: %v, got:%v and %v", method, p, expected, res[i], single)
+			}
+		}
+	}
+
+	// Input data is not reordered
+	if !slices.Equal(data, original) {
+		t.Errorf("expected data not to be modified")
+	}
+
+	if _, err := Percentiles(data, []float64{50, 101}); err != ErrInvalidPercentile {
+		t.Errorf("expected error: %v, got:%v", ErrInvalidPercentile, err)
+	}
+
+	res, err := Percentiles([]float64{1, math.NaN()}, []float64{50, 90})
+	if err != nil || len(res) != 2 || !math.IsNaN(res[0]) || !math.IsNaN(res[1]) {
+		t.Errorf("expected propagated NaN percentiles, got:%v (error %v)", res, err)
+	}
+}
+
+func TestSelection(t *testing.T) {
+	r := rand.New(rand.NewSource(11))
+	increasing := make([]float64, 500)
+	decreasing := make([]float64, 500)
+	random := make([]float64, 500)
+	repeated := make([]float64, 500)
+	equal := make([]float64, 500)
+	for i := range increasing {
+		increasing[i] = float64(i)
+		decreasing[i] = float64(-i)
+		random[i] = r.NormFloat64()
+		repeated[i] = float64(r.Intn(3))
+		equal[i] = 1
+	}
+	random[100], random[200] = math.Inf(1), math.Inf(-1)
+
+	for name, data := range map[string][]float64{
+		"increasing": increasing, "decreasing": decreasing, "random": random, "repeated": repeated, "equal": equal,
+	} {
+		sorted := Sort(data)
+		for _, k := range []int{0, 1, 137, 249, 250, 498, 499} {
+			work := append([]float64(nil), data...)
+			if v := selectK(work, k); v != sorted[k] {
+				t.Errorf("expected %v value %d: %v, got:%v", name, k, sorted[k], v)
+			}
+			if slices.Max(work[:k+1]) != sorted[k] || slices.Min(work[k:]) != sorted[k] {
+				t.Errorf("expected %v data partitioned around value %d", name, k)
+			}
+		}
+
+		for _, n := range []int{499, 500} {
+			median, _ := Median(data[:n])
+			sorted := Sort(data[:n])
+			expected := (sorted[(n-1)/2] + sorted[n/2]) / 2
+			if median != expected {
+				t.Errorf("expected %v median of %d values: %v, got:%v", name, n, expected, median)
+			}
+		}
+	}
+}
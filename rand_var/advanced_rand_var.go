@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log"
 	"math"
+
+	"github.com/jaumefe/stats"
 )
 
 /*
@@ -12,7 +14,8 @@ Optimized for large datasets, so it is not necessary to recalculate
 certain statistical parameters if needed for a certain parameter. For example:
 When calculating the variance, it is necessary to obtain first the Mean,
 so by using this variable, the mean is not recomputed.
-All statistical parameters are computed as it was a whole population
+All statistical parameters are computed as it was a whole population,
+unless the sample estimator is set through its options
 */
 type AdvRandVar struct {
 	*RandVar
@@ -80,6 +83,46 @@ func (arv *AdvRandVar) DefineMeta(name, units, timestamp, source, cat string) {
 	}
 }
 
+// Returns the name of a Meta, empty if it is not defined
+func (m *Meta) Name() string {
+	if m == nil {
+		return ""
+	}
+	return m.name
+}
+
+// Returns the units of a Meta, empty if they are not defined
+func (m *Meta) Units() string {
+	if m == nil {
+		return ""
+	}
+	return m.units
+}
+
+// Returns the timestamp of a Meta, empty if it is not defined
+func (m *Meta) Timestamp() string {
+	if m == nil {
+		return ""
+	}
+	return m.timestamp
+}
+
+// Returns the data source of a Meta, empty if it is not defined
+func (m *Meta) Source() string {
+	if m == nil {
+		return ""
+	}
+	return m.src
+}
+
+// Returns the category of a Meta, empty if it is not defined
+func (m *Meta) Category() string {
+	if m == nil {
+		return ""
+	}
+	return m.category
+}
+
 func (arv *AdvRandVar) SetWeight(w []float64) error {
 	if len(w) != len(arv.data) {
 		return fmt.Errorf("length of weight and data are different: Weight:%d, Data: %d", len(w), len(arv.data))
@@ -102,16 +145,33 @@ func (arv *AdvRandVar) updateMedian() {
 
 // Computes the variance of a AdvRandVar
 func (arv *AdvRandVar) updateVariance() {
-	sum := 0.0
-	if len(arv.RandVar.data) == 0 {
-		arv.variance = sum
+	data := arv.values()
+	n := len(data)
+	if n == 0 {
+		arv.variance = 0
 		return
 	}
 
-	for _, v := range arv.RandVar.data {
-		sum += math.Pow((v - arv.mean), 2)
+	sum := arv.summation().Sum(n, func(i int) float64 {
+		return math.Pow((data[i] - arv.mean), 2)
+	})
+	if arv.sample() {
+		if n < 2 {
+			arv.variance = math.NaN()
+			return
+		}
+		arv.variance = sum / float64(n-1)
+		return
 	}
-	arv.variance = sum / float64(len(arv.RandVar.data))
+	arv.variance = sum / float64(n)
+}
+
+// Returns the standard deviation of a AdvRandVar as a whole population, whatever the estimator
+func (arv *AdvRandVar) populationStdDev(n int) float64 {
+	if arv.sample() {
+		return arv.stdDev * math.Sqrt(float64(n-1)/float64(n))
+	}
+	return arv.stdDev
 }
 
 // Computes the standard deviation of a AdvRandVar
@@ -119,36 +179,58 @@ func (arv *AdvRandVar) updateStdDev() {
 	arv.stdDev = math.Sqrt(arv.variance)
 }
 
-// Computes the value of the skewness of a AdvRandVar
+/*
+Computes the value of the skewness of a AdvRandVar, the adjusted Fisher-Pearson G1 with the sample estimator,
+which is NaN when there are less than 3 values
+*/
 func (arv *AdvRandVar) updateSkewness() {
-	sum := 0.0
-	n := len(arv.RandVar.data)
+	data := arv.values()
+	n := len(data)
+	if arv.sample() && n < 3 {
+		arv.skewness = math.NaN()
+		return
+	}
 	if arv.stdDev == 0 || n == 0 {
 		arv.skewness = 0
 		return
 	}
 
-	for v := 0; v < n; v++ {
-		sum += math.Pow((arv.RandVar.data[v] - arv.mean), 3)
-	}
+	sum := arv.summation().Sum(n, func(i int) float64 {
+		return math.Pow((data[i] - arv.mean), 3)
+	})
 
-	arv.skewness = sum / (float64(n) * math.Pow(arv.stdDev, 3))
+	arv.skewness = sum / (float64(n) * math.Pow(arv.populationStdDev(n), 3))
+	if arv.sample() {
+		fn := float64(n)
+		arv.skewness *= math.Sqrt(fn*(fn-1)) / (fn - 2)
+	}
 }
 
-// Computes the value of the kurtosis of a AdvRandVar
+/*
+Computes the value of the kurtosis of a AdvRandVar, which is not the excess one, so the sample estimator
+gives G2 + 3, which is NaN when there are less than 4 values
+*/
 func (arv *AdvRandVar) updateKurtosis() {
+	data := arv.values()
+	n := len(data)
+	if arv.sample() && n < 4 {
+		arv.kurtosis = math.NaN()
+		return
+	}
 	if arv.stdDev == 0 {
 		arv.kurtosis = 0
 		return
 	}
 
-	n := len(arv.RandVar.data)
-	sum := 0.0
-	for v := 0; v < n; v++ {
-		sum += math.Pow((arv.RandVar.data[v] - arv.mean), 4)
-	}
+	sum := arv.summation().Sum(n, func(i int) float64 {
+		return math.Pow((data[i] - arv.mean), 4)
+	})
 
-	arv.kurtosis = (sum / (float64(n) * math.Pow(arv.stdDev, 4)))
+	arv.kurtosis = (sum / (float64(n) * math.Pow(arv.populationStdDev(n), 4)))
+	if arv.sample() {
+		fn := float64(n)
+		arv.kurtosis = ((fn+1)*(arv.kurtosis-3)+6)*(fn-1)/((fn-2)*(fn-3)) + 3
+	}
 }
 
 // Computes the maximum value of the dataset of an AdvRandVar
@@ -171,12 +253,22 @@ func (arv *AdvRandVar) updateWeightedMean() error {
 	if arv.weight == nil {
 		return fmt.Errorf("weight not defined")
 	}
-	w := 0.0
-	for i, v := range arv.data {
-		arv.weightedMean += v * arv.weight[i]
-		w += arv.weight[i]
-	}
-	arv.weightedMean = arv.weightedMean / w
+	// Weights of omitted NaN values are left out as well
+	omit := arv.opts != nil && arv.opts.NaN == stats.OmitNaN
+	n := len(arv.data)
+	sum := arv.summation().Sum(n, func(i int) float64 {
+		if omit && math.IsNaN(arv.data[i]) {
+			return 0
+		}
+		return arv.data[i] * arv.weight[i]
+	})
+	w := arv.summation().Sum(n, func(i int) float64 {
+		if omit && math.IsNaN(arv.data[i]) {
+			return 0
+		}
+		return arv.weight[i]
+	})
+	arv.weightedMean = sum / w
 	return nil
 }
 
@@ -303,3 +395,41 @@ func (arv *AdvRandVar) Range() float64 {
 func (arv *AdvRandVar) WeightedMean() float64 {
 	return arv.weightedMean
 }
+
+/*
+Summary returns a mergeable summary of the data of an AdvRandVar, whose statistics are computed
+as it was a whole population, weighting the values with the weights set by SetWeight, if any.
+Values whose weights are not greater than 0 are left out. Summaries of several variables can be
+merged to obtain the statistics of their concatenated data without gathering it
+*/
+func (arv *AdvRandVar) Summary() *stats.Accumulator {
+	acc := stats.NewAccumulator()
+	if arv == nil || arv.RandVar == nil {
+		return acc
+	}
+
+	if arv.weight == nil {
+		for _, v := range arv.values() {
+			acc.Push(v)
+		}
+		return acc
+	}
+
+	// Weights of omitted NaN values are left out as well
+	omit := arv.opts != nil && arv.opts.NaN == stats.OmitNaN
+	for i, v := range arv.data {
+		if !(omit && math.IsNaN(v)) {
+			acc.PushWeighted(v, arv.weight[i])
+		}
+	}
+	return acc
+}
+
+// Returns the merged summary of several AdvRandVar, as if their data were concatenated
+func Combine(vars ...*AdvRandVar) *stats.Accumulator {
+	acc := stats.NewAccumulator()
+	for _, arv := range vars {
+		acc.Merge(arv.Summary())
+	}
+	return acc
+}
@@ -1,6 +1,7 @@
 package randvar
 
 import (
+	"math"
 	"reflect"
 	"testing"
 	"time"
@@ -59,6 +60,20 @@ func TestDefineMeta(t *testing.T) {
 
 }
 
+func TestMetaGetters(t *testing.T) {
+	arv := NewAdvRandVar([]float64{1.0, 3.5, 2.2})
+	if arv.Category() != "" {
+		t.Errorf("expected empty category without meta, got: %v", arv.Category())
+	}
+
+	arv.DefineMeta("test", "u", time.RFC1123, "src1", "label1")
+	got := []string{arv.Name(), arv.Units(), arv.Timestamp(), arv.Source(), arv.Category()}
+	expected := []string{"test", "u", time.RFC1123, "src1", "label1"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected meta: %v, got: %v", expected, got)
+	}
+}
+
 func TestWeightMean(t *testing.T) {
 	data := []float64{1.0, 3.5, 2.2}
 	arv := NewAdvRandVar(data)
@@ -209,3 +224,63 @@ func TestUpdate(t *testing.T) {
 		t.Errorf("expected null range value and got: %f", arv.Range())
 	}
 }
+
+func TestSummary(t *testing.T) {
+	data := []float64{1.0, 3.5, 2.2, -0.4, 7.1, 2.2}
+	arv := NewAdvRandVar(data)
+	arv.Update(&OptsExclusionUpdate{WeightedMean: true})
+
+	summary := arv.Summary()
+	mean, _ := summary.Mean()
+	variance, _ := summary.Variance()
+	if math.Abs(mean-arv.Mean()) > 1e-12 || math.Abs(variance-arv.Variance()) > 1e-12 {
+		t.Errorf("expected mean %v and variance %v, got:%v and %v", arv.Mean(), arv.Variance(), mean, variance)
+	}
+
+	// Combining the variables of each shard equals summarizing all of their data
+	shards := []*AdvRandVar{NewAdvRandVar(data[:2]), NewAdvRandVar(data[2:5]), NewAdvRandVar(data[5:]), nil}
+	combined := Combine(shards...)
+	if combined.Count() != len(data) {
+		t.Errorf("expected count: %v, got:%v", len(data), combined.Count())
+	}
+
+	for name, get := range map[string][2]func() (float64, error){
+		"mean":     {summary.Mean, combined.Mean},
+		"variance": {summary.Variance, combined.Variance},
+		"skewness": {summary.Skewness, combined.Skewness},
+		"kurtosis": {summary.Kurtosis, combined.Kurtosis},
+		"min":      {summary.Min, combined.Min},
+		"max":      {summary.Max, combined.Max},
+	} {
+		expected, _ := get[0]()
+		got, err := get[1]()
+		if err != nil {
+			t.Fatalf("unexpected error received: %v", err)
+		}
+		if math.Abs(got-expected) > 1e-12 {
+			t.Errorf("expected combined %v: %v, got:%v", name, expected, got)
+		}
+	}
+}
+
+func TestSummaryWeighted(t *testing.T) {
+	data := []float64{1.0, 3.5, 2.2, -0.4, 7.1, 2.2}
+	weights := []float64{0.5, 1.2, 0.75, 2, 0.1, 1}
+	arv := NewAdvRandVar(data)
+	arv.SetWeight(weights)
+	arv.Update(&OptsExclusionUpdate{})
+
+	// Combining weighted shards equals the weighted mean of all of their data
+	first, second := NewAdvRandVar(data[:4]), NewAdvRandVar(data[4:])
+	first.SetWeight(weights[:4])
+	second.SetWeight(weights[4:])
+	combined := Combine(first, second)
+
+	mean, err := combined.Mean()
+	if err != nil || math.Abs(mean-arv.WeightedMean()) > 1e-12 {
+		t.Errorf("expected combined mean: %v, got:%v (error %v)", arv.WeightedMean(), mean, err)
+	}
+	if combined.Count() != len(data) || math.Abs(combined.Weight()-5.55) > 1e-12 {
+		t.Errorf("expected count %v and weight %v, got:%v and %v", len(data), 5.55, combined.Count(), combined.Weight())
+	}
+}
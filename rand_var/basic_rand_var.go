@@ -2,14 +2,17 @@ package randvar
 
 import (
 	"math"
-	"slices"
 
 	"github.com/jaumefe/stats"
 )
 
-// RandVar is a struct that represents a simple set of data of a random variable. All statistical parameters are computed as it was a whole population
+/*
+RandVar is a struct that represents a simple set of data of a random variable. All statistical parameters
+are computed as it was a whole population, unless the sample estimator is set through its options
+*/
 type RandVar struct {
 	data []float64
+	opts *stats.Options
 }
 
 // Creates a new RandVar
@@ -21,120 +24,193 @@ func NewRandVar(data []float64) *RandVar {
 	return rv
 }
 
-// Returns the mean of the data. It will return 0 when data length is 0
-func (rv *RandVar) Mean() float64 {
-	var sum float64
-	n := len(rv.data)
-	if n == 0 {
-		return 0.0
+/*
+Sets the options used to compute the statistical parameters of the random variable,
+such as the summation method or the NaN policy.
+It returns an error, leaving the options unchanged, if NaN values are not allowed and data contains any
+*/
+func (rv *RandVar) SetOptions(opts *stats.Options) error {
+	if opts == nil {
+		rv.opts = nil
+		return nil
 	}
 
-	for _, v := range rv.data {
-		sum += v
+	if opts.NaN == stats.ErrorOnNaN {
+		for _, v := range rv.data {
+			if math.IsNaN(v) {
+				return stats.ErrNaNInData
+			}
+		}
 	}
 
-	return sum / float64(n)
+	o := *opts
+	rv.opts = &o
+	return nil
 }
 
-// Returns the median of the data
-func (rv *RandVar) Median() float64 {
-	n := len(rv.data)
+// Returns the summation method of the random variable
+func (rv *RandVar) summation() stats.Summation {
+	if rv.opts == nil {
+		return stats.NaiveSummation
+	}
+	return rv.opts.Summation
+}
 
-	data := rv.data
-	slices.Sort(data)
+// Returns whether the random variable uses the sample estimator
+func (rv *RandVar) sample() bool {
+	return rv.opts != nil && rv.opts.Estimator == stats.SampleEstimator
+}
 
-	if n == 0 {
-		return 0
+// Returns the data to compute with: without NaN values if they are omitted, otherwise as it is
+func (rv *RandVar) values() []float64 {
+	if rv.opts == nil || rv.opts.NaN != stats.OmitNaN {
+		return rv.data
+	}
+
+	values := make([]float64, 0, len(rv.data))
+	for _, v := range rv.data {
+		if !math.IsNaN(v) {
+			values = append(values, v)
+		}
 	}
+	return values
+}
+
+// Returns a copy of the data of the random variable
+func (rv *RandVar) Data() []float64 {
+	return append([]float64(nil), rv.data...)
+}
 
-	if n%2 == 1 {
-		return data[n/2]
+// Returns the mean of the data. It will return 0 when data length is 0
+func (rv *RandVar) Mean() float64 {
+	data := rv.values()
+	n := len(data)
+	if n == 0 {
+		return 0.0
 	}
 
-	return (data[n/2-1] + data[n/2]) / 2
+	sum := rv.summation().Sum(n, func(i int) float64 {
+		return data[i]
+	})
+	return sum / float64(n)
 }
 
-// Returns the variance of the data. It will return 0 when data length is 0
+// Returns the median of the data. It will return 0 when data length is 0
+func (rv *RandVar) Median() float64 {
+	median, err := stats.MedianWith(rv.data, rv.opts)
+	if err != nil {
+		return 0
+	}
+	return median
+}
+
+/*
+Returns the variance of the data. It will return 0 when data length is 0
+and NaN for a single value with the sample estimator
+*/
 func (rv *RandVar) Variance() float64 {
-	mean := rv.Mean()
-	sum := 0.0
-	n := len(rv.data)
+	data := rv.values()
+	n := len(data)
 	if n == 0 {
 		return 0.0
 	}
 
-	for _, v := range rv.data {
-		sum += math.Pow((v - mean), 2)
+	sum := rv.centralSum(data, 2)
+	if rv.sample() {
+		if n < 2 {
+			return math.NaN()
+		}
+		return sum / float64(n-1)
 	}
 	return sum / float64(n)
 }
 
+// Returns the sum of the deviations of data from its mean raised to a power
+func (rv *RandVar) centralSum(data []float64, power float64) float64 {
+	mean := rv.Mean()
+	return rv.summation().Sum(len(data), func(i int) float64 {
+		return math.Pow((data[i] - mean), power)
+	})
+}
+
 // Returns the standard deviation of the data
 func (rv *RandVar) StdDev() float64 {
 	return math.Sqrt(rv.Variance())
 }
 
-// Returns the value of the skewness of the data and an error when the standard deviation is 0
+// Returns the standard deviation of the data as a whole population, whatever the estimator
+func (rv *RandVar) populationStdDev(data []float64) float64 {
+	if len(data) == 0 {
+		return 0
+	}
+	return math.Sqrt(rv.centralSum(data, 2) / float64(len(data)))
+}
+
+/*
+Returns the value of the skewness of the data, the adjusted Fisher-Pearson G1 with the sample estimator.
+It returns an error when the standard deviation is 0 or there are less than 3 values with the sample estimator
+*/
 func (rv *RandVar) Skewness() (float64, error) {
-	stdDev := rv.StdDev()
+	data := rv.values()
+	n := len(data)
+	if rv.sample() && n < 3 {
+		return 0, stats.ErrNotEnoughData
+	}
+
+	stdDev := rv.populationStdDev(data)
 	if stdDev == 0 {
 		return 0, stats.ErrNullStdDeviation
 	}
 
-	n := len(rv.data)
-	mean := rv.Mean()
-	sum := 0.0
-	for v := 0; v < n; v++ {
-		sum += math.Pow((rv.data[v] - mean), 3)
+	sum := rv.centralSum(data, 3)
+	g1 := sum / (float64(n) * math.Pow(stdDev, 3))
+	if rv.sample() {
+		fn := float64(n)
+		return g1 * math.Sqrt(fn*(fn-1)) / (fn - 2), nil
 	}
-
-	return sum / (float64(n) * math.Pow(stdDev, 3)), nil
+	return g1, nil
 }
 
-// Returns the value of the kurtosis of the data and an error when the standard deviation is 0
+/*
+Returns the value of the kurtosis of the data, which is not the excess one, so the sample estimator gives G2 + 3.
+It returns an error when the standard deviation is 0 or there are less than 4 values with the sample estimator
+*/
 func (rv *RandVar) Kurtosis() (float64, error) {
-	stdDev := rv.StdDev()
+	data := rv.values()
+	n := len(data)
+	if rv.sample() && n < 4 {
+		return 0, stats.ErrNotEnoughData
+	}
+
+	stdDev := rv.populationStdDev(data)
 	if stdDev == 0 {
 		return 0, stats.ErrNullStdDeviation
 	}
 
-	n := len(rv.data)
-	mean := rv.Mean()
-	sum := 0.0
-	for v := 0; v < n; v++ {
-		sum += math.Pow((rv.data[v] - mean), 4)
+	sum := rv.centralSum(data, 4)
+	kurtosis := (sum / (float64(n) * math.Pow(stdDev, 4)))
+	if rv.sample() {
+		fn := float64(n)
+		return ((fn+1)*(kurtosis-3)+6)*(fn-1)/((fn-2)*(fn-3)) + 3, nil
 	}
-
-	return (sum / (float64(n) * math.Pow(stdDev, 4))), nil
+	return kurtosis, nil
 }
 
-// Returns the maximum value of the data
+// Returns the maximum value of the data. It will return 0 when data length is 0
 func (rv *RandVar) Max() float64 {
-	if len(rv.data) == 0 {
+	max, err := stats.MaxWith(rv.data, rv.opts)
+	if err != nil {
 		return 0
 	}
-
-	max := rv.data[0]
-	for _, v := range rv.data {
-		if v > max {
-			max = v
-		}
-	}
 	return max
 }
 
-// Returns the minimum value of the data
+// Returns the minimum value of the data. It will return 0 when data length is 0
 func (rv *RandVar) Min() float64 {
-	if len(rv.data) == 0 {
+	min, err := stats.MinWith(rv.data, rv.opts)
+	if err != nil {
 		return 0
 	}
-
-	min := rv.data[0]
-	for _, v := range rv.data {
-		if v < min {
-			min = v
-		}
-	}
 	return min
 }
 
@@ -143,19 +219,64 @@ func (rv *RandVar) Range() float64 {
 	return rv.Max() - rv.Min()
 }
 
-// Returns the covariance between two random variables
+/*
+Returns the percentile of the data given a percentage(%) value, estimated with the quantile method of its options.
+It returns an error if the data is empty or the percentage is out of range (0 - 100%)
+*/
+func (rv *RandVar) Percentile(p float64) (float64, error) {
+	return stats.PercentileWith(rv.data, p, rv.opts)
+}
+
+/*
+Returns the quantile of the data given a quantile index qs out of n quantiles, estimated with the quantile method
+of its options. It returns an error if the data is empty or the quantile index is out of range (0 - n)
+*/
+func (rv *RandVar) Quantile(qs float64, n uint) (float64, error) {
+	return stats.QuantileWith(rv.data, qs, n, rv.opts)
+}
+
+// Returns the interquartile range of the data, whose quartiles are estimated with the quantile method of its options
+func (rv *RandVar) IQR() (float64, error) {
+	return stats.IQRWith(rv.data, rv.opts)
+}
+
+/*
+Returns the covariance between two random variables, with n - 1 denominator for the sample estimator.
+When NaN values are omitted, pairs of values where any of them is NaN are left out
+*/
 func (rv *RandVar) Covariance(rv1 *RandVar) (float64, error) {
 	if len(rv.data) != len(rv1.data) {
 		return 0, stats.ErrDifferentLength
 	}
 
-	n := len(rv.data)
-	mean := rv.Mean()
-	meanRV1 := rv1.Mean()
-	cov := 0.0
-	for i := 0; i < n; i++ {
-		cov += (rv.data[i] - mean) * (rv1.data[i] - meanRV1)
+	x, y := rv.data, rv1.data
+	if rv.opts != nil && rv.opts.NaN == stats.OmitNaN {
+		x, y = nil, nil
+		for i := range rv.data {
+			if !math.IsNaN(rv.data[i]) && !math.IsNaN(rv1.data[i]) {
+				x = append(x, rv.data[i])
+				y = append(y, rv1.data[i])
+			}
+		}
 	}
 
+	n := len(x)
+	s := rv.summation()
+	mean := s.Sum(n, func(i int) float64 {
+		return x[i]
+	}) / float64(n)
+	meanRV1 := s.Sum(n, func(i int) float64 {
+		return y[i]
+	}) / float64(n)
+	cov := s.Sum(n, func(i int) float64 {
+		return (x[i] - mean) * (y[i] - meanRV1)
+	})
+
+	if rv.sample() {
+		if n < 2 {
+			return 0, stats.ErrNotEnoughData
+		}
+		return cov / float64(n-1), nil
+	}
 	return cov / float64(n), nil
 }
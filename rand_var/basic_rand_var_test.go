@@ -1,8 +1,11 @@
 package randvar
 
 import (
+	"math"
 	"reflect"
 	"testing"
+
+	"github.com/jaumefe/stats"
 )
 
 type testrv struct {
@@ -26,6 +29,22 @@ func TestNewRandVar(t *testing.T) {
 	}
 }
 
+func TestData(t *testing.T) {
+	data := []float64{1.0, 3.5, 2.2}
+	rv := NewRandVar(data)
+
+	got := rv.Data()
+	if !reflect.DeepEqual(data, got) {
+		t.Errorf("Expected %v, but got %v", data, got)
+	}
+
+	// The returned data must be a copy
+	got[0] = 22.2
+	if rv.data[0] == got[0] {
+		t.Errorf("A modification on returned data has modified the data of the random variable")
+	}
+}
+
 func TestMean(t *testing.T) {
 	tests := []testrv{
 		{
@@ -308,3 +327,140 @@ func TestCovariance(t *testing.T) {
 		t.Errorf("Length of both random variables must be equal: len(x):%d; len(y):%d", len(x.data), len(y.data))
 	}
 }
+
+func TestSetOptions(t *testing.T) {
+	rv := NewRandVar([]float64{1, 1e100, 1, -1e100})
+	if mean := rv.Mean(); mean != 0 {
+		t.Errorf("Expected naive summation mean %f, got %f", 0.0, mean)
+	}
+
+	opts := &stats.Options{Summation: stats.NeumaierSummation}
+	rv.SetOptions(opts)
+	opts.Summation = stats.NaiveSummation
+	if mean := rv.Mean(); mean != 0.5 {
+		t.Errorf("Expected compensated summation mean %f, got %f", 0.5, mean)
+	}
+
+	rv.SetOptions(nil)
+	if mean := rv.Mean(); mean != 0 {
+		t.Errorf("Expected naive summation mean %f, got %f", 0.0, mean)
+	}
+
+	// AdvRandVar parameters use the options of its random variable
+	arv := NewAdvRandVar([]float64{1, 1e100, 1, -1e100})
+	arv.SetOptions(&stats.Options{Summation: stats.NeumaierSummation})
+	if err := arv.SetWeight([]float64{1, 1, 1, 1}); err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+	arv.Update(&OptsExclusionUpdate{})
+	if arv.Mean() != 0.5 || arv.WeightedMean() != 0.5 {
+		t.Errorf("Expected compensated summation mean and weighted mean %f, got %f and %f", 0.5, arv.Mean(), arv.WeightedMean())
+	}
+}
+
+func TestNaNPolicy(t *testing.T) {
+	data := []float64{2, math.NaN(), 4, 9}
+	rv := NewRandVar(data)
+	for name, v := range map[string]float64{"mean": rv.Mean(), "median": rv.Median(), "variance": rv.Variance(), "max": rv.Max(), "min": rv.Min()} {
+		if !math.IsNaN(v) {
+			t.Errorf("Expected propagated NaN %v, got %f", name, v)
+		}
+	}
+
+	if err := rv.SetOptions(&stats.Options{NaN: stats.ErrorOnNaN}); err != stats.ErrNaNInData {
+		t.Errorf("Expected error %v, got %v", stats.ErrNaNInData, err)
+	}
+
+	if err := rv.SetOptions(&stats.Options{NaN: stats.OmitNaN}); err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+	clean := NewRandVar([]float64{2, 4, 9})
+	for name, v := range map[string][2]float64{
+		"mean":     {rv.Mean(), clean.Mean()},
+		"median":   {rv.Median(), clean.Median()},
+		"variance": {rv.Variance(), clean.Variance()},
+		"max":      {rv.Max(), clean.Max()},
+		"min":      {rv.Min(), clean.Min()},
+	} {
+		if v[0] != v[1] {
+			t.Errorf("Expected %v without NaN %f, got %f", name, v[1], v[0])
+		}
+	}
+
+	// Pairs with any NaN value are left out of the covariance
+	other := NewRandVar([]float64{1, 5, math.NaN(), 3})
+	cov, err := rv.Covariance(other)
+	if err != nil || cov != 3.5 {
+		t.Errorf("Expected covariance %f, got %f (error %v)", 3.5, cov, err)
+	}
+
+	// Data is not modified by the computations
+	if !reflect.DeepEqual(rv.Data()[2:], data[2:]) || !math.IsNaN(rv.Data()[1]) {
+		t.Errorf("Expected data %v, got %v", data, rv.Data())
+	}
+
+	arv := NewAdvRandVar(data)
+	arv.SetOptions(&stats.Options{NaN: stats.OmitNaN})
+	arv.SetWeight([]float64{1, 100, 1, 2})
+	arv.Update(&OptsExclusionUpdate{})
+	if arv.Mean() != 5 || arv.WeightedMean() != 6 || arv.Max() != 9 {
+		t.Errorf("Expected mean, weighted mean and maximum without NaN 5, 6 and 9, got %f, %f and %f", arv.Mean(), arv.WeightedMean(), arv.Max())
+	}
+	if n := arv.Summary().Count(); n != 3 {
+		t.Errorf("Expected summary without NaN values, got %d values", n)
+	}
+}
+
+func TestEstimator(t *testing.T) {
+	data := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	rv := NewRandVar(data)
+	rv.SetOptions(&stats.Options{Estimator: stats.SampleEstimator})
+	other := NewRandVar([]float64{1, 3, 2, 5, 4, 6, 8, 7})
+
+	skewness, _ := rv.Skewness()
+	kurtosis, _ := rv.Kurtosis()
+	cov, _ := rv.Covariance(other)
+	arv := NewAdvRandVar(data)
+	arv.SetOptions(&stats.Options{Estimator: stats.SampleEstimator})
+	arv.Update(&OptsExclusionUpdate{WeightedMean: true})
+
+	for name, v := range map[string][2]float64{
+		"variance":          {rv.Variance(), 32.0 / 7},
+		"std":               {rv.StdDev(), math.Sqrt(32.0 / 7)},
+		"skewness":          {skewness, 0.8184875533567997},
+		"kurtosis":          {kurtosis, 3.940625},
+		"covariance":        {cov, 31.0 / 7},
+		"advanced std":      {arv.StdDev(), math.Sqrt(32.0 / 7)},
+		"advanced skew":     {arv.Skewness(), 0.8184875533567997},
+		"advanced kurtosis": {arv.Kurtosis(), 3.940625},
+	} {
+		if math.Abs(v[0]-v[1]) > 1e-12 {
+			t.Errorf("Expected sample %v %f, got %f", name, v[1], v[0])
+		}
+	}
+
+	short := NewRandVar(data[:3])
+	short.SetOptions(&stats.Options{Estimator: stats.SampleEstimator})
+	if _, err := short.Kurtosis(); err != stats.ErrNotEnoughData {
+		t.Errorf("Expected error %v, got %v", stats.ErrNotEnoughData, err)
+	}
+}
+
+func TestQuantileMethod(t *testing.T) {
+	rv := NewRandVar([]float64{7, 1, 3, 9, 4, 12, 6, 2, 10, 5})
+	if p, err := rv.Percentile(10); err != nil || math.Abs(p-1.1) > 1e-12 {
+		t.Errorf("Expected default percentile %f, got %f (error %v)", 1.1, p, err)
+	}
+
+	rv.SetOptions(&stats.Options{Quantile: stats.QuantileType7})
+	p, _ := rv.Percentile(10)
+	q, _ := rv.Quantile(1, 4)
+	iqr, _ := rv.IQR()
+	if math.Abs(p-1.9) > 1e-12 || q != 3.25 || iqr != 5.25 {
+		t.Errorf("Expected type 7 percentile, quartile and interquartile range 1.9, 3.25 and 5.25, got %f, %f and %f", p, q, iqr)
+	}
+
+	if _, err := rv.Percentile(101); err != stats.ErrInvalidPercentile {
+		t.Errorf("Expected error %v, got %v", stats.ErrInvalidPercentile, err)
+	}
+}
@@ -0,0 +1,243 @@
+package resample
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/jaumefe/stats"
+	"github.com/jaumefe/stats/distuv"
+	"github.com/jaumefe/stats/shuffle"
+)
+
+// Statistic computes a value out of a sample, such as stats.Mean or stats.Median
+type Statistic func([]float64) (float64, error)
+
+const (
+	defaultResamples       = 2000
+	defaultConfidenceLevel = 0.95
+)
+
+/*
+BootstrapOptions to set special features of the bootstrap:
+  - Resamples: number of bootstrap resamples, 2000 by default
+  - ConfidenceLevel: confidence level of the intervals, 95% by default
+  - Seed: seed for random number generator, a time based one by default
+  - Workers: number of goroutines computing the resamples, 1 by default.
+    Results do not depend on it, but the statistic must be safe for concurrent use
+*/
+type BootstrapOptions struct {
+	Resamples       int
+	ConfidenceLevel float64
+	Seed            int64
+	Workers         int
+}
+
+/*
+BootstrapResult holds the outcome of a bootstrap:
+  - Estimate: statistic of the original sample
+  - Replicates: statistic of each resample
+  - StdErr: bootstrap standard error, the standard deviation of the replicates
+  - Bias: mean of the replicates minus the estimate
+  - Percentile: percentile confidence interval
+  - Basic: basic (reverse percentile) confidence interval
+  - BCa: bias-corrected and accelerated confidence interval
+*/
+type BootstrapResult struct {
+	Estimate   float64
+	Replicates []float64
+	StdErr     float64
+	Bias       float64
+	Percentile [2]float64
+	Basic      [2]float64
+	BCa        [2]float64
+}
+
+// Returns a seeded random generator, using a time based seed when seed is 0
+func newGenerator(seed int64) *rand.Rand {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return rand.New(rand.NewSource(seed))
+}
+
+// Returns n non null seeds drawn from r, so that each resample can be reproduced on its own
+func seeds(r *rand.Rand, n int) []int64 {
+	s := make([]int64, n)
+	for i := range s {
+		for s[i] == 0 {
+			s[i] = r.Int63()
+		}
+	}
+	return s
+}
+
+/*
+Runs task for every index in [0, n) splitting them between workers goroutines.
+It returns the first error found, if any
+*/
+func parallel(n, workers int, task func(i int) error) error {
+	workers = max(1, min(workers, n))
+	errs := make([]error, workers)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < n; i += workers {
+				if err := task(i); err != nil {
+					errs[w] = err
+					return
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+Bootstrap resamples data with replacement to estimate the standard error and bias of a statistic
+and its percentile, basic and BCa confidence intervals.
+It returns an error if data has less than 2 values, the options are not valid or the statistic fails
+*/
+func Bootstrap(data []float64, stat Statistic, opts *BootstrapOptions) (*BootstrapResult, error) {
+	if opts == nil {
+		opts = &BootstrapOptions{}
+	}
+
+	n := len(data)
+	if n == 0 {
+		return nil, stats.ErrEmptyData
+	}
+	if n < 2 {
+		return nil, ErrNotEnoughData
+	}
+
+	resamples := opts.Resamples
+	if resamples < 0 {
+		return nil, ErrInvalidResamples
+	}
+	if resamples == 0 {
+		resamples = defaultResamples
+	}
+
+	level := opts.ConfidenceLevel
+	if level == 0 {
+		level = defaultConfidenceLevel
+	}
+	if !(level > 0 && level < 1) {
+		return nil, ErrInvalidConfidenceLevel
+	}
+
+	estimate, err := stat(data)
+	if err != nil {
+		return nil, err
+	}
+
+	s := seeds(newGenerator(opts.Seed), resamples)
+	replicates := make([]float64, resamples)
+	err = parallel(resamples, opts.Workers, func(i int) error {
+		v, err := stat(shuffle.Resample(data, &shuffle.ShuffleOptions{Seed: s[i]}))
+		replicates[i] = v
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mean, _ := stats.Mean(replicates)
+	stdErr, _ := stats.StandardDeviationWith(replicates, &stats.Options{Estimator: stats.SampleEstimator})
+	res := &BootstrapResult{
+		Estimate:   estimate,
+		Replicates: replicates,
+		StdErr:     stdErr,
+		Bias:       mean - estimate,
+	}
+
+	alpha := 1 - level
+	bounds, _ := stats.Percentiles(replicates, []float64{100 * alpha / 2, 100 * (1 - alpha/2)})
+	lower, upper := bounds[0], bounds[1]
+	res.Percentile = [2]float64{lower, upper}
+	res.Basic = [2]float64{2*estimate - upper, 2*estimate - lower}
+
+	res.BCa, err = bcaInterval(data, stat, estimate, replicates, alpha)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+/*
+Returns the bias-corrected and accelerated interval: the bias correction comes from the proportion
+of replicates below the estimate and the acceleration from the skewness of the jackknife values
+*/
+func bcaInterval(data []float64, stat Statistic, estimate float64, replicates []float64, alpha float64) ([2]float64, error) {
+	below := 0
+	for _, v := range replicates {
+		if v < estimate {
+			below++
+		}
+	}
+
+	normal, _ := distuv.NewNormal(0, 1)
+	z0 := normal.Quantile(float64(below) / float64(len(replicates)))
+	if math.IsInf(z0, 0) || math.IsNaN(z0) {
+		// All replicates lie on the same side of the estimate, so there is no bias correction to make
+		z0 = 0
+	}
+
+	values, err := leaveOneOut(data, stat)
+	if err != nil {
+		return [2]float64{}, err
+	}
+
+	mean, _ := stats.Mean(values)
+	num, den := 0.0, 0.0
+	for _, v := range values {
+		d := mean - v
+		num += d * d * d
+		den += d * d
+	}
+	a := 0.0
+	if den > 0 {
+		a = num / (6 * math.Pow(den, 1.5))
+	}
+
+	var bounds [2]float64
+	for i, p := range []float64{alpha / 2, 1 - alpha/2} {
+		z := z0 + normal.Quantile(p)
+		adjusted := normal.CDF(z0 + z/(1-a*z))
+		bounds[i], _ = stats.Percentile(replicates, 100*adjusted)
+	}
+
+	return bounds, nil
+}
+
+// Returns the statistic of each sample leaving one of the values of data out
+func leaveOneOut(data []float64, stat Statistic) ([]float64, error) {
+	n := len(data)
+	values := make([]float64, n)
+	sample := make([]float64, n-1)
+	for i := range data {
+		copy(sample, data[:i])
+		copy(sample[i:], data[i+1:])
+
+		v, err := stat(sample)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+
+	return values, nil
+}
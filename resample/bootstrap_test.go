@@ -0,0 +1,111 @@
+package resample
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/jaumefe/stats"
+)
+
+// Student's sleep data: extra hours of sleep given by a soporific drug on 10 patients
+var sleep = []float64{0.7, -1.6, -0.2, -1.2, -0.1, 3.4, 3.7, 0.8, 0.0, 2.0}
+
+func TestBootstrapMean(t *testing.T) {
+	res, err := Bootstrap(sleep, stats.Mean, &BootstrapOptions{Resamples: 5000, Seed: 42})
+	if err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+
+	if res.Estimate != 0.75 {
+		t.Errorf("expected estimate: %v, got:%v", 0.75, res.Estimate)
+	}
+
+	if len(res.Replicates) != 5000 {
+		t.Errorf("expected replicates: %v, got:%v", 5000, len(res.Replicates))
+	}
+
+	// The bootstrap standard error of the mean tends to the plug-in one, σ/√n
+	variance, _ := stats.Variance(sleep)
+	expected := math.Sqrt(variance / float64(len(sleep)))
+	if math.Abs(res.StdErr-expected) > 0.05*expected {
+		t.Errorf("expected standard error: %v, got:%v", expected, res.StdErr)
+	}
+
+	if math.Abs(res.Bias) > 3*expected/math.Sqrt(5000) {
+		t.Errorf("expected null bias, got:%v", res.Bias)
+	}
+
+	for name, ci := range map[string][2]float64{"percentile": res.Percentile, "basic": res.Basic, "BCa": res.BCa} {
+		if !(ci[0] < res.Estimate && res.Estimate < ci[1]) {
+			t.Errorf("expected %v interval around the estimate, got:%v", name, ci)
+		}
+	}
+
+	if math.Abs(res.Basic[0]+res.Percentile[1]-2*res.Estimate) > 1e-12 {
+		t.Errorf("expected basic interval to mirror the percentile one: %v, got:%v", res.Percentile, res.Basic)
+	}
+}
+
+func TestBootstrapReproducibility(t *testing.T) {
+	sequential, err := Bootstrap(sleep, stats.Median, &BootstrapOptions{Resamples: 500, Seed: 7})
+	if err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+
+	concurrent, err := Bootstrap(sleep, stats.Median, &BootstrapOptions{Resamples: 500, Seed: 7, Workers: 4})
+	if err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+
+	if !reflect.DeepEqual(sequential, concurrent) {
+		t.Errorf("expected the same results with the same seed, got:%v and %v", sequential, concurrent)
+	}
+
+	other, _ := Bootstrap(sleep, stats.Median, &BootstrapOptions{Resamples: 500, Seed: 8})
+	if reflect.DeepEqual(sequential.Replicates, other.Replicates) {
+		t.Errorf("expected different replicates with different seeds")
+	}
+}
+
+func TestBootstrapConstantData(t *testing.T) {
+	res, err := Bootstrap([]float64{2, 2, 2, 2}, stats.Mean, &BootstrapOptions{Resamples: 100, Seed: 1})
+	if err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+
+	expected := [2]float64{2, 2}
+	if res.StdErr != 0 || res.Percentile != expected || res.Basic != expected || res.BCa != expected {
+		t.Errorf("expected degenerate intervals at 2, got:%v", res)
+	}
+}
+
+func TestBootstrapErrors(t *testing.T) {
+	errStat := errors.New("statistic error")
+	failing := func(data []float64) (float64, error) { return 0, errStat }
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"Empty data", second(Bootstrap(nil, stats.Mean, nil)), stats.ErrEmptyData},
+		{"Single value", second(Bootstrap([]float64{1}, stats.Mean, nil)), ErrNotEnoughData},
+		{"Negative resamples", second(Bootstrap(sleep, stats.Mean, &BootstrapOptions{Resamples: -1})), ErrInvalidResamples},
+		{"Invalid confidence level", second(Bootstrap(sleep, stats.Mean, &BootstrapOptions{ConfidenceLevel: 1.5})), ErrInvalidConfidenceLevel},
+		{"Failing statistic", second(Bootstrap(sleep, failing, &BootstrapOptions{Workers: 3})), errStat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err != tt.want {
+				t.Errorf("expected error: %v, got:%v", tt.want, tt.err)
+			}
+		})
+	}
+}
+
+func second[T any](_ T, err error) error {
+	return err
+}
@@ -0,0 +1,6 @@
+/*
+Package resample provides resampling methods to assess the uncertainty of any statistic
+given as a func([]float64) (float64, error), such as stats.Mean or stats.Median.
+Resamples are drawn through the shuffle package and are reproducible when a seed is set.
+*/
+package resample
@@ -0,0 +1,11 @@
+package resample
+
+import "errors"
+
+var (
+	ErrInvalidConfidenceLevel = errors.New("confidence level must be between 0 and 1")
+	ErrInvalidDeletion        = errors.New("number of deleted values must be between 1 and the number of values minus 1")
+	ErrInvalidResamples       = errors.New("number of resamples must be greater or equal than 0")
+	ErrNotEnoughData          = errors.New("not enough data to resample")
+	ErrTooManySubsets         = errors.New("too many subsets to enumerate")
+)
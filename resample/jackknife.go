@@ -0,0 +1,137 @@
+package resample
+
+import (
+	"math"
+
+	"github.com/jaumefe/stats"
+)
+
+// Largest number of subsets the delete-d jackknife and the exact permutation tests enumerate
+const maxSubsets = 1 << 20
+
+/*
+JackknifeResult holds the outcome of a jackknife:
+  - Estimate: statistic of the original sample
+  - Jackknife: bias-corrected estimate, the mean of the pseudo-values
+  - Bias: jackknife estimate of the bias of the statistic
+  - StdErr: jackknife standard error
+  - Values: statistic of each subsample
+  - PseudoValues: pseudo-value of each subsample, whose mean and spread give the estimate and its standard error
+*/
+type JackknifeResult struct {
+	Estimate     float64
+	Jackknife    float64
+	Bias         float64
+	StdErr       float64
+	Values       []float64
+	PseudoValues []float64
+}
+
+/*
+Jackknife leaves each value of data out in turn to estimate the bias and standard error of a statistic.
+Values and pseudo-values are in the same order as the left out values.
+It returns an error if data has less than 2 values or the statistic fails
+*/
+func Jackknife(data []float64, stat Statistic) (*JackknifeResult, error) {
+	n := len(data)
+	if n == 0 {
+		return nil, stats.ErrEmptyData
+	}
+	if n < 2 {
+		return nil, ErrNotEnoughData
+	}
+
+	estimate, err := stat(data)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := leaveOneOut(data, stat)
+	if err != nil {
+		return nil, err
+	}
+
+	return jackknifeResult(estimate, values, n, 1), nil
+}
+
+/*
+JackknifeDeleteD leaves every subset of d values of data out to estimate the bias and standard error of a statistic,
+which is consistent for non-smooth statistics, such as the median, when d grows with the number of values.
+Subsets are enumerated in lexicographic order of the left out indices, so their number, n choose d, must be moderate.
+It returns an error if data is empty, d is out of range (1 - n-1), there are too many subsets or the statistic fails
+*/
+func JackknifeDeleteD(data []float64, stat Statistic, d int) (*JackknifeResult, error) {
+	n := len(data)
+	if n == 0 {
+		return nil, stats.ErrEmptyData
+	}
+	if d < 1 || d >= n {
+		return nil, ErrInvalidDeletion
+	}
+	if binomialCoefficient(n, d) > maxSubsets {
+		return nil, ErrTooManySubsets
+	}
+
+	estimate, err := stat(data)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := make([]int, d)
+	for i := range idx {
+		idx[i] = i
+	}
+
+	values := make([]float64, 0, int(binomialCoefficient(n, d)))
+	sample := make([]float64, n-d)
+	for {
+		k, next := 0, 0
+		for i, v := range data {
+			if next < d && idx[next] == i {
+				next++
+				continue
+			}
+			sample[k] = v
+			k++
+		}
+
+		v, err := stat(sample)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+
+		if !nextCombination(idx, n) {
+			break
+		}
+	}
+
+	return jackknifeResult(estimate, values, n, d), nil
+}
+
+/*
+Builds the jackknife result out of the statistic of the n values and those of the subsamples leaving d values out:
+the pseudo-value of a subsample S is (n·θ - (n-d)·θ_S) / d and the variance is (n-d) / (d·N) · Σ(θ_S - mean θ_S)²,
+with N subsamples
+*/
+func jackknifeResult(estimate float64, values []float64, n, d int) *JackknifeResult {
+	fn, fd := float64(n), float64(d)
+	mean, _ := stats.Mean(values)
+
+	ss := 0.0
+	pseudo := make([]float64, len(values))
+	for i, v := range values {
+		ss += (v - mean) * (v - mean)
+		pseudo[i] = (fn*estimate - (fn-fd)*v) / fd
+	}
+
+	bias := (fn - fd) / fd * (mean - estimate)
+	return &JackknifeResult{
+		Estimate:     estimate,
+		Jackknife:    estimate - bias,
+		Bias:         bias,
+		StdErr:       math.Sqrt((fn - fd) / (fd * float64(len(values))) * ss),
+		Values:       values,
+		PseudoValues: pseudo,
+	}
+}
@@ -0,0 +1,146 @@
+package resample
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/jaumefe/stats"
+)
+
+func TestJackknife(t *testing.T) {
+	n := float64(len(sleep))
+	variance, _ := stats.Variance(sleep)
+	sampleVariance := variance * n / (n - 1)
+
+	tests := []struct {
+		name      string
+		stat      Statistic
+		estimate  float64
+		jackknife float64
+		bias      float64
+	}{
+		// The mean is unbiased and its pseudo-values are the data themselves
+		{"Mean", stats.Mean, 0.75, 0.75, 0},
+		// The jackknife removes the bias of the population variance, giving the sample one
+		{"Variance", stats.Variance, variance, sampleVariance, variance - sampleVariance},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := Jackknife(sleep, tt.stat)
+			if err != nil {
+				t.Fatalf("unexpected error received: %v", err)
+			}
+
+			if math.Abs(res.Estimate-tt.estimate) > 1e-12 {
+				t.Errorf("expected estimate: %v, got:%v", tt.estimate, res.Estimate)
+			}
+
+			if math.Abs(res.Jackknife-tt.jackknife) > 1e-12 {
+				t.Errorf("expected jackknife estimate: %v, got:%v", tt.jackknife, res.Jackknife)
+			}
+
+			if math.Abs(res.Bias-tt.bias) > 1e-12 {
+				t.Errorf("expected bias: %v, got:%v", tt.bias, res.Bias)
+			}
+
+			if len(res.Values) != len(sleep) || len(res.PseudoValues) != len(sleep) {
+				t.Errorf("expected %v values and pseudo-values, got:%v and %v", len(sleep), len(res.Values), len(res.PseudoValues))
+			}
+
+			// The standard error is the one of the mean of the pseudo-values
+			mean, _ := stats.Mean(res.PseudoValues)
+			pseudoVariance, _ := stats.Variance(res.PseudoValues)
+			if math.Abs(mean-res.Jackknife) > 1e-12 {
+				t.Errorf("expected mean of pseudo-values: %v, got:%v", res.Jackknife, mean)
+			}
+			expected := math.Sqrt(pseudoVariance / (n - 1))
+			if math.Abs(res.StdErr-expected) > 1e-12 {
+				t.Errorf("expected standard error: %v, got:%v", expected, res.StdErr)
+			}
+		})
+	}
+
+	res, _ := Jackknife(sleep, stats.Mean)
+	for i, v := range res.PseudoValues {
+		if math.Abs(v-sleep[i]) > 1e-12 {
+			t.Errorf("expected pseudo-value: %v, got:%v", sleep[i], v)
+		}
+	}
+
+	// Standard error of the mean, s/√n
+	expected := math.Sqrt(sampleVariance / n)
+	if math.Abs(res.StdErr-expected) > 1e-12 {
+		t.Errorf("expected standard error: %v, got:%v", expected, res.StdErr)
+	}
+}
+
+func TestJackknifeDeleteD(t *testing.T) {
+	n := float64(len(sleep))
+	variance, _ := stats.Variance(sleep)
+	expected := math.Sqrt(variance / (n - 1))
+
+	one, err := Jackknife(sleep, stats.Median)
+	if err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+	deleteOne, err := JackknifeDeleteD(sleep, stats.Median, 1)
+	if err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+
+	if math.Abs(one.StdErr-deleteOne.StdErr) > 1e-12 || math.Abs(one.Bias-deleteOne.Bias) > 1e-12 {
+		t.Errorf("expected the leave-one-out jackknife: %+v, got:%+v", one, deleteOne)
+	}
+
+	for d := 1; d < len(sleep)-1; d++ {
+		res, err := JackknifeDeleteD(sleep, stats.Mean, d)
+		if err != nil {
+			t.Fatalf("unexpected error received: %v", err)
+		}
+
+		subsets := binomialCoefficient(len(sleep), d)
+		if float64(len(res.Values)) != subsets {
+			t.Errorf("expected %v subsets, got:%v", subsets, len(res.Values))
+		}
+
+		// The mean being linear, any d gives its standard error and no bias
+		if math.Abs(res.StdErr-expected) > 1e-12 {
+			t.Errorf("expected standard error with d = %v: %v, got:%v", d, expected, res.StdErr)
+		}
+
+		if math.Abs(res.Bias) > 1e-12 {
+			t.Errorf("expected null bias with d = %v, got:%v", d, res.Bias)
+		}
+	}
+}
+
+func TestJackknifeErrors(t *testing.T) {
+	errStat := errors.New("statistic failed")
+	failing := func([]float64) (float64, error) { return 0, errStat }
+	large := make([]float64, 100)
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"Empty data", second(Jackknife(nil, stats.Mean)), stats.ErrEmptyData},
+		{"Single value", second(Jackknife([]float64{1}, stats.Mean)), ErrNotEnoughData},
+		{"Failing statistic", second(Jackknife(sleep, failing)), errStat},
+		{"Delete-d empty data", second(JackknifeDeleteD(nil, stats.Mean, 1)), stats.ErrEmptyData},
+		{"Null deletion", second(JackknifeDeleteD(sleep, stats.Mean, 0)), ErrInvalidDeletion},
+		{"Deleting all values", second(JackknifeDeleteD(sleep, stats.Mean, len(sleep))), ErrInvalidDeletion},
+		{"Too many subsets", second(JackknifeDeleteD(large, stats.Mean, 50)), ErrTooManySubsets},
+		{"Delete-d failing statistic", second(JackknifeDeleteD(sleep, failing, 2)), errStat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err != tt.want {
+				t.Errorf("expected error: %v, got:%v", tt.want, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package resample
+
+import (
+	"math"
+
+	"github.com/jaumefe/stats"
+	"github.com/jaumefe/stats/hypothesis"
+	"github.com/jaumefe/stats/mathext"
+	"github.com/jaumefe/stats/shuffle"
+)
+
+// TwoSampleStatistic computes a value comparing two samples, such as DifferenceOfMeans
+type TwoSampleStatistic func(x, y []float64) (float64, error)
+
+// DifferenceOfMeans returns the mean of x minus the mean of y
+func DifferenceOfMeans(x, y []float64) (float64, error) {
+	mx, err := stats.Mean(x)
+	if err != nil {
+		return 0, err
+	}
+
+	my, err := stats.Mean(y)
+	if err != nil {
+		return 0, err
+	}
+
+	return mx - my, nil
+}
+
+// DifferenceOfMedians returns the median of x minus the median of y
+func DifferenceOfMedians(x, y []float64) (float64, error) {
+	mx, err := stats.Median(x)
+	if err != nil {
+		return 0, err
+	}
+
+	my, err := stats.Median(y)
+	if err != nil {
+		return 0, err
+	}
+
+	return mx - my, nil
+}
+
+const defaultPermutations = 9999
+
+/*
+PermutationMethod defines how the permutation distribution is built:
+  - AutoPermutation: exact when there are no more arrangements than Monte Carlo permutations
+  - ExactPermutation: enumerates every way of splitting the pooled samples, up to 2^20 of them
+  - MonteCarloPermutation: draws random permutations of the pooled samples
+*/
+type PermutationMethod int
+
+const (
+	AutoPermutation PermutationMethod = iota
+	ExactPermutation
+	MonteCarloPermutation
+)
+
+/*
+PermutationOptions to set special features of the permutation tests:
+  - Alternative: alternative hypothesis, two-sided by default. Greater means large values of the statistic
+  - Method: how the permutation distribution is built
+  - Permutations: number of Monte Carlo permutations, 9999 by default
+  - Seed: seed for random number generator, a time based one by default
+*/
+type PermutationOptions struct {
+	Alternative  hypothesis.Alternative
+	Method       PermutationMethod
+	Permutations int
+	Seed         int64
+}
+
+/*
+PermutationResult holds the outcome of a permutation test:
+  - Statistic: statistic of the observed samples
+  - PValue: p-value for the chosen alternative
+  - Distribution: statistic of each permutation, which includes the observed one when exact
+  - Exact: whether every arrangement was enumerated
+*/
+type PermutationResult struct {
+	Statistic    float64
+	PValue       float64
+	Distribution []float64
+	Exact        bool
+}
+
+// Returns the number of ways of choosing k out of n elements as a float, to avoid overflows
+func binomialCoefficient(n, k int) float64 {
+	return math.Round(math.Exp(mathext.Lgamma(float64(n+1)) - mathext.Lgamma(float64(k+1)) - mathext.Lgamma(float64(n-k+1))))
+}
+
+/*
+PermutationTest tests whether two samples come from the same distribution comparing the statistic
+of the observed samples with its distribution over the rearrangements of the pooled values.
+Exact p-values are the proportion of arrangements at least as extreme as the observed one,
+while Monte Carlo ones count the observed samples as one more permutation.
+It returns an error if any of the samples is empty, the options are not valid, there are too many
+arrangements to enumerate them exactly or the statistic fails
+*/
+func PermutationTest(x, y []float64, stat TwoSampleStatistic, opts *PermutationOptions) (*PermutationResult, error) {
+	if opts == nil {
+		opts = &PermutationOptions{}
+	}
+
+	n1, n2 := len(x), len(y)
+	if n1 == 0 || n2 == 0 {
+		return nil, stats.ErrEmptyData
+	}
+
+	permutations := opts.Permutations
+	if permutations < 0 {
+		return nil, ErrInvalidResamples
+	}
+	if permutations == 0 {
+		permutations = defaultPermutations
+	}
+	if opts.Method == ExactPermutation && binomialCoefficient(n1+n2, n1) > maxSubsets {
+		return nil, ErrTooManySubsets
+	}
+
+	observed, err := stat(x, y)
+	if err != nil {
+		return nil, err
+	}
+
+	pooled := append(append(make([]float64, 0, n1+n2), x...), y...)
+	exact := useExactPermutations(opts.Method, n1, n2, permutations)
+
+	var dist []float64
+	if exact {
+		dist, err = exactPermutations(pooled, n1, stat)
+	} else {
+		dist, err = monteCarloPermutations(pooled, n1, stat, permutations, opts.Seed)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Statistics are compared with a tolerance, as equivalent arrangements may differ by rounding errors
+	tol := 1e-10 * math.Max(1, math.Abs(observed))
+	lower, upper := 0, 0
+	for _, v := range dist {
+		if v <= observed+tol {
+			lower++
+		}
+		if v >= observed-tol {
+			upper++
+		}
+	}
+
+	total := float64(len(dist))
+	pLess, pGreater := float64(lower)/total, float64(upper)/total
+	if !exact {
+		pLess = float64(lower+1) / (total + 1)
+		pGreater = float64(upper+1) / (total + 1)
+	}
+
+	res := &PermutationResult{Statistic: observed, Distribution: dist, Exact: exact}
+	switch opts.Alternative {
+	case hypothesis.Less:
+		res.PValue = pLess
+	case hypothesis.Greater:
+		res.PValue = pGreater
+	default:
+		res.PValue = math.Min(1, 2*math.Min(pLess, pGreater))
+	}
+
+	return res, nil
+}
+
+/*
+Returns whether the permutation distribution of samples of sizes n1 and n2 is enumerated exactly, which
+the automatic method does when there are no more arrangements than Monte Carlo permutations, up to 2^20
+*/
+func useExactPermutations(method PermutationMethod, n1, n2, permutations int) bool {
+	switch method {
+	case ExactPermutation:
+		return true
+	case AutoPermutation:
+		return binomialCoefficient(n1+n2, n1) <= float64(min(permutations, maxSubsets))
+	default:
+		return false
+	}
+}
+
+// Returns the statistic of every way of splitting pooled into a first sample of size n1 and the rest
+func exactPermutations(pooled []float64, n1 int, stat TwoSampleStatistic) ([]float64, error) {
+	n := len(pooled)
+	idx := make([]int, n1)
+	for i := range idx {
+		idx[i] = i
+	}
+
+	dist := make([]float64, 0, int(binomialCoefficient(n, n1)))
+	x := make([]float64, n1)
+	y := make([]float64, n-n1)
+	in := make([]bool, n)
+	for {
+		clear(in)
+		for i, j := range idx {
+			x[i] = pooled[j]
+			in[j] = true
+		}
+		k := 0
+		for j, v := range pooled {
+			if !in[j] {
+				y[k] = v
+				k++
+			}
+		}
+
+		v, err := stat(x, y)
+		if err != nil {
+			return nil, err
+		}
+		dist = append(dist, v)
+
+		if !nextCombination(idx, n) {
+			return dist, nil
+		}
+	}
+}
+
+// Advances idx, a sorted combination of indices out of [0, n), to the next one in lexicographic order.
+// It returns false when idx was the last combination
+func nextCombination(idx []int, n int) bool {
+	k := len(idx)
+	i := k - 1
+	for i >= 0 && idx[i] == n-k+i {
+		i--
+	}
+	if i < 0 {
+		return false
+	}
+
+	idx[i]++
+	for j := i + 1; j < k; j++ {
+		idx[j] = idx[j-1] + 1
+	}
+	return true
+}
+
+// Returns the statistic of random permutations of pooled, split into a first sample of size n1 and the rest
+func monteCarloPermutations(pooled []float64, n1 int, stat TwoSampleStatistic, permutations int, seed int64) ([]float64, error) {
+	s := seeds(newGenerator(seed), permutations)
+	dist := make([]float64, permutations)
+	perm := make([]float64, len(pooled))
+	for i := range dist {
+		copy(perm, pooled)
+		shuffle.FisherYatesShuffle(perm, &shuffle.ShuffleOptions{Seed: s[i]})
+
+		v, err := stat(perm[:n1:n1], perm[n1:])
+		if err != nil {
+			return nil, err
+		}
+		dist[i] = v
+	}
+
+	return dist, nil
+}
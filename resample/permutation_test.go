@@ -0,0 +1,136 @@
+package resample
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/jaumefe/stats"
+	"github.com/jaumefe/stats/hypothesis"
+)
+
+// Ranks of two samples from R's wilcox.test examples, whose exact one-sided p-value is 0.1272
+var (
+	ranksX = []float64{3, 4, 14, 7, 11, 10, 15, 13, 1, 12}
+	ranksY = []float64{8, 5, 6, 2, 9}
+)
+
+func TestPermutationTestExact(t *testing.T) {
+	tests := []struct {
+		name     string
+		x, y     []float64
+		opts     *PermutationOptions
+		expected float64
+	}{
+		{"Most extreme arrangement", []float64{1, 2, 3}, []float64{4, 5, 6, 7}, &PermutationOptions{Alternative: hypothesis.Less}, 1.0 / 35},
+		{"Most extreme arrangement two-sided", []float64{1, 2, 3}, []float64{4, 5, 6, 7}, nil, 2.0 / 35},
+		// Difference of rank means is equivalent to the Mann-Whitney U statistic
+		{"Mann-Whitney", ranksX, ranksY, &PermutationOptions{Alternative: hypothesis.Greater}, 0.1272061272061272},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := PermutationTest(tt.x, tt.y, DifferenceOfMeans, tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error received: %v", err)
+			}
+
+			if !res.Exact {
+				t.Errorf("expected exact distribution")
+			}
+
+			total := binomialCoefficient(len(tt.x)+len(tt.y), len(tt.x))
+			if float64(len(res.Distribution)) != total {
+				t.Errorf("expected distribution size: %v, got:%v", total, len(res.Distribution))
+			}
+
+			if math.Abs(res.PValue-tt.expected) > 1e-12 {
+				t.Errorf("expected p-value: %v, got:%v", tt.expected, res.PValue)
+			}
+		})
+	}
+}
+
+func TestPermutationTestMonteCarlo(t *testing.T) {
+	opts := &PermutationOptions{Alternative: hypothesis.Greater, Method: MonteCarloPermutation, Permutations: 10000, Seed: 3}
+	res, err := PermutationTest(ranksX, ranksY, DifferenceOfMeans, opts)
+	if err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+
+	if res.Exact || len(res.Distribution) != 10000 {
+		t.Errorf("expected 10000 Monte Carlo permutations, got:%v (exact %v)", len(res.Distribution), res.Exact)
+	}
+
+	if math.Abs(res.PValue-0.1272061272061272) > 0.015 {
+		t.Errorf("expected p-value: %v, got:%v", 0.1272061272061272, res.PValue)
+	}
+
+	again, _ := PermutationTest(ranksX, ranksY, DifferenceOfMeans, opts)
+	if !reflect.DeepEqual(res, again) {
+		t.Errorf("expected the same results with the same seed")
+	}
+}
+
+func TestPermutationTestAuto(t *testing.T) {
+	// 8 + 8 values can be arranged in 12870 ways, more than the default Monte Carlo permutations
+	x := []float64{1, 2, 3, 4, 5, 6, 7, 8}
+	y := []float64{9, 10, 11, 12, 13, 14, 15, 16}
+	res, err := PermutationTest(x, y, DifferenceOfMedians, &PermutationOptions{Seed: 1})
+	if err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+
+	if res.Exact || len(res.Distribution) != defaultPermutations {
+		t.Errorf("expected %v Monte Carlo permutations, got:%v (exact %v)", defaultPermutations, len(res.Distribution), res.Exact)
+	}
+
+	if res.Statistic != -8 {
+		t.Errorf("expected statistic: %v, got:%v", -8, res.Statistic)
+	}
+}
+
+func TestUseExactPermutations(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       PermutationMethod
+		n1, n2       int
+		permutations int
+		expected     bool
+	}{
+		{"Few arrangements", AutoPermutation, 8, 8, 20000, true},
+		{"More arrangements than permutations", AutoPermutation, 8, 8, 10000, false},
+		// 12 + 12 values can be arranged in 2704156 ways, more than the subsets that are enumerated
+		{"Too many arrangements", AutoPermutation, 12, 12, 1 << 30, false},
+		{"Exact", ExactPermutation, 12, 12, 10, true},
+		{"Monte Carlo", MonteCarloPermutation, 2, 2, 10000, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := useExactPermutations(tt.method, tt.n1, tt.n2, tt.permutations); got != tt.expected {
+				t.Errorf("expected exact: %v, got:%v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestPermutationTestErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"Empty sample", second(PermutationTest(nil, ranksY, DifferenceOfMeans, nil)), stats.ErrEmptyData},
+		{"Negative permutations", second(PermutationTest(ranksX, ranksY, DifferenceOfMeans, &PermutationOptions{Permutations: -1})), ErrInvalidResamples},
+		{"Too many arrangements", second(PermutationTest(make([]float64, 30), make([]float64, 30), DifferenceOfMeans, &PermutationOptions{Method: ExactPermutation})), ErrTooManySubsets},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err != tt.want {
+				t.Errorf("expected error: %v, got:%v", tt.want, tt.err)
+			}
+		})
+	}
+}
@@ -44,11 +44,10 @@ func FisherYatesShuffle[T any](arr []T, opts *ShuffleOptions) {
 		}
 	}
 
-	for i := n - 1; i > 0; i-- {
-		if len(excludeIndices) == 0 || !excludeIndices[i] {
-			j := validIndices[r.Intn(len(validIndices))]
-			arr[i], arr[j] = arr[j], arr[i]
-		}
-
+	// Each position is swapped with one of the positions not yet placed, including itself,
+	// so that every permutation of the valid indices is equally likely
+	for k := len(validIndices) - 1; k > 0; k-- {
+		i, j := validIndices[k], validIndices[r.Intn(k+1)]
+		arr[i], arr[j] = arr[j], arr[i]
 	}
 }
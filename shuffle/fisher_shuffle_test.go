@@ -1,13 +1,14 @@
 package shuffle
 
 import (
+	"math"
 	"reflect"
 	"testing"
 )
 
 func TestFisherYatesShuffleWithExclusion(t *testing.T) {
 	input := []any{0, "foo", 2, 3.2, 4, true, 6, 7, 8, "bar"}
-	expected := []any{6, "foo", 2, 8, 0, true, 4, 7, "bar", 3.2}
+	expected := []any{0, "foo", 2, 6, "bar", 4, 3.2, 7, 8, true}
 	opts := &ShuffleOptions{Seed: int64(3), ExcludeIndices: []int{1, 2, 7}}
 
 	// Copying original input
@@ -48,7 +49,7 @@ func TestFisherYatesShuffleWithExclusion(t *testing.T) {
 func TestFisherYatesShuffle(t *testing.T) {
 	input := []any{0, "foo", 2, 3, 4, true, 6, 7.2, 8, "bar"}
 	opts := &ShuffleOptions{Seed: int64(3)}
-	expected := []any{"bar", 8, true, 0, "foo", 6, 3, 2, 7.2, 4}
+	expected := []any{"foo", "bar", 3, 7.2, 4, true, 6, 0, 2, 8}
 
 	// Copying original input
 	shuffled := make([]any, len(input))
@@ -78,6 +79,27 @@ func TestFisherYatesShuffle(t *testing.T) {
 	}
 }
 
+func TestFisherYatesShuffleUniform(t *testing.T) {
+	// Every permutation of 3 elements should come up about a sixth of the times
+	const shuffles = 12000
+	counts := make(map[[3]int]int)
+	for seed := int64(1); seed <= shuffles; seed++ {
+		arr := []int{0, 1, 2}
+		FisherYatesShuffle(arr, &ShuffleOptions{Seed: seed})
+		counts[[3]int{arr[0], arr[1], arr[2]}]++
+	}
+
+	if len(counts) != 6 {
+		t.Errorf("Expected 6 permutations, got %v", len(counts))
+	}
+
+	for perm, count := range counts {
+		if math.Abs(float64(count)/shuffles-1.0/6) > 0.02 {
+			t.Errorf("Expected frequency of %v close to %v, got %v", perm, 1.0/6, float64(count)/shuffles)
+		}
+	}
+}
+
 func TestFisherYatesShuffleEmptySlice(t *testing.T) {
 	input := []any{}
 	opts := &ShuffleOptions{Seed: int64(3)}
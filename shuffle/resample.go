@@ -0,0 +1,48 @@
+package shuffle
+
+import (
+	"math/rand"
+	"time"
+)
+
+/*
+Resample draws with replacement as many elements as arr holds, returning them in a new slice
+and leaving arr untouched. Elements at excluded indices keep their position and are not drawn
+*/
+func Resample[T any](arr []T, opts *ShuffleOptions) []T {
+	seed := time.Now().UnixNano()
+	excludeIndices := make(map[int]bool)
+
+	if opts != nil {
+		if opts.Seed != 0 {
+			seed = opts.Seed
+		}
+
+		if opts.ExcludeIndices != nil {
+			for _, ei := range opts.ExcludeIndices {
+				excludeIndices[ei] = true
+			}
+		}
+	}
+
+	r := rand.New(rand.NewSource(seed))
+	n := len(arr)
+
+	validIndices := make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		if len(excludeIndices) == 0 || !excludeIndices[i] {
+			validIndices = append(validIndices, i)
+		}
+	}
+
+	resampled := make([]T, n)
+	for i := 0; i < n; i++ {
+		if len(excludeIndices) == 0 || !excludeIndices[i] {
+			resampled[i] = arr[validIndices[r.Intn(len(validIndices))]]
+		} else {
+			resampled[i] = arr[i]
+		}
+	}
+
+	return resampled
+}
@@ -0,0 +1,63 @@
+package shuffle
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResample(t *testing.T) {
+	input := []any{0, "foo", 2, 3.2, 4, true, 6, 7, 8, "bar"}
+	original := make([]any, len(input))
+	copy(original, input)
+
+	opts := &ShuffleOptions{Seed: int64(3)}
+	resampled := Resample(input, opts)
+
+	// Input must not be modified
+	if !reflect.DeepEqual(input, original) {
+		t.Errorf("Expected input %v, got %v", original, input)
+	}
+
+	// Same seed must give the same resample
+	if again := Resample(input, opts); !reflect.DeepEqual(resampled, again) {
+		t.Errorf("Expected %v, got %v", resampled, again)
+	}
+
+	// Checking that all elements come from the input
+	originalMap := make(map[any]bool)
+	for _, in := range input {
+		originalMap[in] = true
+	}
+
+	for _, r := range resampled {
+		if !originalMap[r] {
+			t.Errorf("Resampled element %v is not in the input", r)
+		}
+	}
+}
+
+func TestResampleWithExclusion(t *testing.T) {
+	input := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	opts := &ShuffleOptions{Seed: int64(3), ExcludeIndices: []int{1, 2, 7}}
+	resampled := Resample(input, opts)
+
+	// Checking that exclusions has not moved
+	for _, ei := range opts.ExcludeIndices {
+		if resampled[ei] != input[ei] {
+			t.Errorf("Excluded index %d was altered: expected %v, got %v", ei, input[ei], resampled[ei])
+		}
+	}
+
+	// Checking that excluded elements are not drawn elsewhere
+	for i, r := range resampled {
+		if (r == 1 || r == 2 || r == 7) && r != i {
+			t.Errorf("Excluded element %v was drawn at index %d", r, i)
+		}
+	}
+}
+
+func TestResampleEmptySlice(t *testing.T) {
+	if resampled := Resample([]int{}, nil); len(resampled) != 0 {
+		t.Errorf("Expected empty resample, got %v", resampled)
+	}
+}
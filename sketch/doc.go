@@ -0,0 +1,6 @@
+/*
+Package sketch provides summaries of streams of values that use bounded memory to answer
+quantile and rank queries approximately, which can be merged so that summaries of several
+partitions of the data can be combined into a summary of all of it.
+*/
+package sketch
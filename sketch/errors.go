@@ -0,0 +1,17 @@
+package sketch
+
+import "errors"
+
+var (
+	ErrInvalidCompression = errors.New("compression must be a finite value greater than 0")
+	ErrIncompatibleSketch = errors.New("sketches with different parameters can not be merged")
+	ErrInvalidCount       = errors.New("count must be greater than 0")
+	ErrInvalidDigits      = errors.New("significant digits must be between 1 and 5")
+	ErrInvalidEncoding    = errors.New("invalid encoded sketch")
+	ErrInvalidK           = errors.New("accuracy parameter must be greater or equal than 0")
+	ErrInvalidQuantile    = errors.New("quantile must be between 0 and 1")
+	ErrInvalidRange       = errors.New("lowest trackable value must be at least 1 and the highest one at least twice it")
+	ErrInvalidValue       = errors.New("value must be a finite number")
+	ErrNegativeCount      = errors.New("values subtracted more times than they were recorded")
+	ErrValueOutOfRange    = errors.New("value out of the trackable range")
+)
@@ -0,0 +1,313 @@
+package sketch
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/jaumefe/stats"
+)
+
+/*
+Histogram is a high dynamic range histogram of Tene, which counts integer values such as latencies
+in microseconds into buckets whose width grows with their values, so that any value between 0 and
+the highest trackable one is counted with a given number of significant decimal digits using a fixed
+amount of memory. Buckets are grouped into powers of two, each of them split into as many linear
+sub-buckets as needed to keep the resolution: every recorded value is represented by a range of
+equivalent values whose width is at most 10^-digits times the value.
+The zero value is not usable, histograms must be created with NewHistogram
+*/
+type Histogram struct {
+	highest int64
+
+	unitMagnitude               int
+	subBucketHalfCountMagnitude int
+	subBucketCount              int
+	subBucketHalfCount          int
+	subBucketMask               int64
+
+	counts []int64
+	total  int64
+}
+
+/*
+Bucket of a histogram: the number of recorded values between Low and High, both of them included,
+which are all equivalent at the resolution of the histogram
+*/
+type Bucket struct {
+	Low   int64
+	High  int64
+	Count int64
+}
+
+/*
+NewHistogram returns an empty histogram tracking values from 0 up to highest, with unit resolution
+down to lowest and the given number of significant decimal digits everywhere.
+It returns an error if lowest is lower than 1, highest is lower than twice lowest
+or digits are not between 1 and 5
+*/
+func NewHistogram(lowest, highest int64, digits int) (*Histogram, error) {
+	if lowest < 1 || highest/2 < lowest {
+		return nil, ErrInvalidRange
+	}
+	if digits < 1 || digits > 5 {
+		return nil, ErrInvalidDigits
+	}
+
+	// Sub-buckets of a bucket are enough to count values up to 2·10^digits with unit resolution
+	subBucketCountMagnitude := int(math.Ceil(math.Log2(2 * math.Pow10(digits))))
+	h := &Histogram{
+		highest:                     highest,
+		unitMagnitude:               bits.Len64(uint64(lowest)) - 1,
+		subBucketHalfCountMagnitude: subBucketCountMagnitude - 1,
+		subBucketCount:              1 << subBucketCountMagnitude,
+		subBucketHalfCount:          1 << (subBucketCountMagnitude - 1),
+	}
+	h.subBucketMask = int64(h.subBucketCount-1) << h.unitMagnitude
+
+	// Buckets double the range of the previous one until the highest value is trackable
+	buckets := 1
+	for smallestUntrackable := int64(h.subBucketCount) << h.unitMagnitude; smallestUntrackable <= highest; buckets++ {
+		if smallestUntrackable > math.MaxInt64/2 {
+			buckets++
+			break
+		}
+		smallestUntrackable <<= 1
+	}
+	h.counts = make([]int64, (buckets+1)*h.subBucketHalfCount)
+	return h, nil
+}
+
+// Returns the bucket and sub-bucket of a value, which must be between 0 and the highest trackable one
+func (h *Histogram) indices(v int64) (bucket, subBucket int) {
+	bucket = bits.Len64(uint64(v|h.subBucketMask)) - h.unitMagnitude - (h.subBucketHalfCountMagnitude + 1)
+	subBucket = int(v >> (bucket + h.unitMagnitude))
+	return bucket, subBucket
+}
+
+// Returns the index of the counts of a value, which must be between 0 and the highest trackable one
+func (h *Histogram) countsIndex(v int64) int {
+	bucket, subBucket := h.indices(v)
+	return (bucket+1)<<h.subBucketHalfCountMagnitude + subBucket - h.subBucketHalfCount
+}
+
+// Returns the range of values equivalent to those counted at an index of the counts
+func (h *Histogram) bucketRange(i int) (low, high int64) {
+	bucket := i>>h.subBucketHalfCountMagnitude - 1
+	subBucket := i&(h.subBucketHalfCount-1) + h.subBucketHalfCount
+	if bucket < 0 {
+		subBucket -= h.subBucketHalfCount
+		bucket = 0
+	}
+
+	low = int64(subBucket) << (bucket + h.unitMagnitude)
+	return low, low + int64(1)<<(bucket+h.unitMagnitude) - 1
+}
+
+/*
+Record counts a value in the histogram.
+It returns an error if the value is out of the trackable range
+*/
+func (h *Histogram) Record(v int64) error {
+	return h.RecordN(v, 1)
+}
+
+/*
+RecordN counts a value n times in the histogram.
+It returns an error if the value is out of the trackable range or n is not greater than 0
+*/
+func (h *Histogram) RecordN(v, n int64) error {
+	if v < 0 || v > h.highest {
+		return ErrValueOutOfRange
+	}
+	if n < 1 {
+		return ErrInvalidCount
+	}
+
+	h.counts[h.countsIndex(v)] += n
+	h.total += n
+	return nil
+}
+
+// Returns the number of values recorded in the histogram
+func (h *Histogram) Count() int64 {
+	return h.total
+}
+
+// Removes every recorded value of the histogram
+func (h *Histogram) Reset() {
+	clear(h.counts)
+	h.total = 0
+}
+
+// Returns the non empty buckets of the histogram sorted by their values
+func (h *Histogram) Buckets() []Bucket {
+	var res []Bucket
+	for i, c := range h.counts {
+		if c != 0 {
+			low, high := h.bucketRange(i)
+			res = append(res, Bucket{Low: low, High: high, Count: c})
+		}
+	}
+	return res
+}
+
+/*
+Merge adds the values recorded in another histogram to this one, which may have a different
+configuration, so values are taken at the resolution of the other histogram.
+It returns an error if any of the values is out of the trackable range, without modifying the histogram
+*/
+func (h *Histogram) Merge(other *Histogram) error {
+	if other == nil {
+		return nil
+	}
+
+	buckets := other.Buckets()
+	if len(buckets) > 0 && buckets[len(buckets)-1].Low > h.highest {
+		return ErrValueOutOfRange
+	}
+
+	for _, b := range buckets {
+		h.counts[h.countsIndex(b.Low)] += b.Count
+		h.total += b.Count
+	}
+	return nil
+}
+
+/*
+Subtract removes the values recorded in another histogram from this one, such as an earlier snapshot
+of it to obtain the values recorded in between. Values are taken at the resolution of the other histogram.
+It returns an error if any of the values is out of the trackable range or was not recorded in the histogram
+as many times, without modifying the histogram
+*/
+func (h *Histogram) Subtract(other *Histogram) error {
+	if other == nil {
+		return nil
+	}
+
+	buckets := other.Buckets()
+	if len(buckets) > 0 && buckets[len(buckets)-1].Low > h.highest {
+		return ErrValueOutOfRange
+	}
+
+	// Values of several buckets of the other histogram may fall into the same bucket of this one
+	removed := make(map[int]int64, len(buckets))
+	for _, b := range buckets {
+		i := h.countsIndex(b.Low)
+		removed[i] += b.Count
+		if removed[i] > h.counts[i] {
+			return ErrNegativeCount
+		}
+	}
+
+	for i, c := range removed {
+		h.counts[i] -= c
+		h.total -= c
+	}
+	return nil
+}
+
+// Returns the lowest recorded value, at the resolution of the histogram, and an error when it is empty
+func (h *Histogram) Min() (int64, error) {
+	for i, c := range h.counts {
+		if c != 0 {
+			low, _ := h.bucketRange(i)
+			return low, nil
+		}
+	}
+	return 0, stats.ErrEmptyData
+}
+
+// Returns the highest recorded value, at the resolution of the histogram, and an error when it is empty
+func (h *Histogram) Max() (int64, error) {
+	for i := len(h.counts) - 1; i >= 0; i-- {
+		if h.counts[i] != 0 {
+			_, high := h.bucketRange(i)
+			return high, nil
+		}
+	}
+	return 0, stats.ErrEmptyData
+}
+
+/*
+Percentile returns the percentile of the recorded values given a percentage(%) value, as the type 1
+percentile of stats.Percentile, which is the smallest value not lower than that percentage of the values.
+It returns the highest value equivalent to it, so the exact percentile is at most 10^-digits times lower.
+It returns an error if the histogram is empty or the percentage is out of range (0 - 100%)
+*/
+func (h *Histogram) Percentile(p float64) (int64, error) {
+	if h.total == 0 {
+		return 0, stats.ErrEmptyData
+	}
+	if p < 0 || p > 100 {
+		return 0, stats.ErrInvalidPercentile
+	}
+
+	rank := quantileRank(p/100, h.total)
+	var cumulative int64
+	for i, c := range h.counts {
+		cumulative += c
+		if cumulative >= rank {
+			_, high := h.bucketRange(i)
+			return high, nil
+		}
+	}
+	return h.Max()
+}
+
+/*
+Mean returns the mean of the recorded values, each of them taken as the middle of its equivalent values.
+It returns an error if the histogram is empty
+*/
+func (h *Histogram) Mean() (float64, error) {
+	if h.total == 0 {
+		return 0, stats.ErrEmptyData
+	}
+
+	sum := 0.0
+	for i, c := range h.counts {
+		if c != 0 {
+			sum += float64(c) * h.middle(i)
+		}
+	}
+	return sum / float64(h.total), nil
+}
+
+/*
+StandardDeviation returns the standard deviation of the recorded values as a whole population,
+each of them taken as the middle of its equivalent values.
+It returns an error if the histogram is empty
+*/
+func (h *Histogram) StandardDeviation() (float64, error) {
+	mean, err := h.Mean()
+	if err != nil {
+		return 0, err
+	}
+
+	sum := 0.0
+	for i, c := range h.counts {
+		if c != 0 {
+			d := h.middle(i) - mean
+			sum += float64(c) * d * d
+		}
+	}
+	return math.Sqrt(sum / float64(h.total)), nil
+}
+
+/*
+Returns the rank, counting from 1, of the type 1 quantile q of n values: the smallest rank not lower than q·n.
+Ranks a few rounding errors above an integer are taken as it, as stats.Quantile does
+*/
+func quantileRank(q float64, n int64) int64 {
+	r := q * float64(n)
+	rank := int64(math.Ceil(r))
+	if f := math.Floor(r); r-f <= 4*0x1p-52*math.Max(1, r) {
+		rank = int64(f)
+	}
+	return max(rank, 1)
+}
+
+// Returns the middle of the values equivalent to those counted at an index of the counts, which is exact for single values
+func (h *Histogram) middle(i int) float64 {
+	low, high := h.bucketRange(i)
+	return float64(low + (high-low+1)/2)
+}
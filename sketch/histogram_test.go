@@ -0,0 +1,220 @@
+package sketch
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/jaumefe/stats"
+)
+
+// Returns latencies in microseconds, log-uniformly distributed from 1 microsecond to 1 minute
+func latencies(r *rand.Rand, n int) []int64 {
+	res := make([]int64, n)
+	for i := range res {
+		res[i] = int64(math.Exp(r.Float64() * math.Log(60e6)))
+	}
+	return res
+}
+
+// Returns a histogram of data with a given configuration, failing on errors
+func newHistogram(t *testing.T, lowest, highest int64, digits int, data []int64) *Histogram {
+	t.Helper()
+	h, err := NewHistogram(lowest, highest, digits)
+	if err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+	for _, v := range data {
+		if err := h.Record(v); err != nil {
+			t.Fatalf("unexpected error received: %v", err)
+		}
+	}
+	return h
+}
+
+func TestHistogramPercentile(t *testing.T) {
+	data := latencies(rand.New(rand.NewSource(1)), 20000)
+	floats := make([]float64, len(data))
+	for i, v := range data {
+		floats[i] = float64(v)
+	}
+
+	for _, digits := range []int{1, 2, 3, 4} {
+		h := newHistogram(t, 1, 3600e6, digits, data)
+		for _, p := range []float64{0, 1, 10, 25, 50, 75, 90, 99, 99.9, 99.99, 100} {
+			res, err := h.Percentile(p)
+			if err != nil {
+				t.Fatalf("unexpected error received: %v", err)
+			}
+
+			// The exact type 1 percentile is in the same bucket, at most 10^-digits lower
+			exact, _ := stats.PercentileWith(floats, p, &stats.Options{Quantile: stats.QuantileType1})
+			if float64(res) < exact || float64(res)-exact > exact*math.Pow10(-digits) {
+				t.Errorf("expected %d digits percentile %v close to %v, got:%v", digits, p, exact, res)
+			}
+		}
+
+		mean, _ := h.Mean()
+		std, _ := h.StandardDeviation()
+		exactMean, _ := stats.Mean(floats)
+		exactStd, _ := stats.StandardDeviation(floats)
+		if math.Abs(mean-exactMean) > exactMean*math.Pow10(-digits) || math.Abs(std-exactStd) > exactStd*math.Pow10(-digits) {
+			t.Errorf("expected %d digits mean and standard deviation %v and %v, got:%v and %v", digits, exactMean, exactStd, mean, std)
+		}
+	}
+}
+
+func TestHistogramSmallValues(t *testing.T) {
+	data := []int64{7, 1, 3, 9, 4, 12, 6, 2, 10, 5, 0}
+	h := newHistogram(t, 1, 1000, 2, data)
+
+	// Values below 2·10^digits are counted with unit resolution
+	floats := make([]float64, len(data))
+	for i, v := range data {
+		floats[i] = float64(v)
+	}
+	for _, p := range []float64{0, 10, 30, 50, 54.5, 90, 100} {
+		expected, _ := stats.PercentileWith(floats, p, &stats.Options{Quantile: stats.QuantileType1})
+		if res, err := h.Percentile(p); err != nil || float64(res) != expected {
+			t.Errorf("expected percentile %v: %v, got:%v (error %v)", p, expected, res, err)
+		}
+	}
+
+	min, _ := h.Min()
+	max, _ := h.Max()
+	mean, _ := h.Mean()
+	if min != 0 || max != 12 || mean != 59.0/11 {
+		t.Errorf("expected minimum, maximum and mean 0, 12 and %v, got:%v, %v and %v", 59.0/11, min, max, mean)
+	}
+}
+
+func TestHistogramBuckets(t *testing.T) {
+	data := latencies(rand.New(rand.NewSource(2)), 5000)
+	h := newHistogram(t, 1, 3600e6, 3, data)
+	if err := h.RecordN(1500000, 1000); err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+
+	buckets := h.Buckets()
+	count := int64(0)
+	for i, b := range buckets {
+		count += b.Count
+		if b.Count <= 0 || b.High < b.Low || (i > 0 && b.Low <= buckets[i-1].High) {
+			t.Errorf("expected sorted non empty buckets, got:%+v", b)
+		}
+		if width := b.High - b.Low + 1; width > 1 && float64(width) > float64(b.Low)/1000 {
+			t.Errorf("expected bucket width lower than a thousandth of its values, got:%+v", b)
+		}
+	}
+	if count != h.Count() || count != 6000 {
+		t.Errorf("expected count: %v, got:%v and %v", 6000, count, h.Count())
+	}
+
+	// Recording a value n times at once is equivalent to recording it one by one
+	other := newHistogram(t, 1, 3600e6, 3, data)
+	for i := 0; i < 1000; i++ {
+		other.Record(1500000)
+	}
+	if !reflect.DeepEqual(other.Buckets(), buckets) {
+		t.Errorf("expected the same buckets recording values one by one")
+	}
+
+	h.Reset()
+	if h.Count() != 0 || len(h.Buckets()) != 0 {
+		t.Errorf("expected empty histogram, got %v values", h.Count())
+	}
+}
+
+func TestHistogramMergeSubtract(t *testing.T) {
+	data := latencies(rand.New(rand.NewSource(3)), 10000)
+	whole := newHistogram(t, 1, 3600e6, 3, data)
+	first := newHistogram(t, 1, 3600e6, 3, data[:4000])
+	second := newHistogram(t, 1, 3600e6, 3, data[4000:])
+
+	merged := newHistogram(t, 1, 3600e6, 3, nil)
+	for _, h := range []*Histogram{first, second} {
+		if err := merged.Merge(h); err != nil {
+			t.Fatalf("unexpected error received: %v", err)
+		}
+	}
+	if !reflect.DeepEqual(merged.Buckets(), whole.Buckets()) || merged.Count() != whole.Count() {
+		t.Errorf("expected merged histogram equal to the whole one")
+	}
+
+	// Values recorded in an interval are those of the current snapshot minus the earlier one
+	if err := merged.Subtract(first); err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+	if !reflect.DeepEqual(merged.Buckets(), second.Buckets()) || merged.Count() != second.Count() {
+		t.Errorf("expected subtracted histogram equal to the second one")
+	}
+
+	if err := first.Subtract(whole); err != ErrNegativeCount {
+		t.Errorf("expected error: %v, got:%v", ErrNegativeCount, err)
+	}
+	if first.Count() != 4000 {
+		t.Errorf("expected unmodified histogram with %v values, got:%v", 4000, first.Count())
+	}
+
+	// Nil histograms have no values to merge or subtract
+	if err := first.Merge(nil); err != nil || first.Count() != 4000 {
+		t.Errorf("expected unmodified histogram with %v values, got:%v (error %v)", 4000, first.Count(), err)
+	}
+	if err := first.Subtract(nil); err != nil || first.Count() != 4000 {
+		t.Errorf("expected unmodified histogram with %v values, got:%v (error %v)", 4000, first.Count(), err)
+	}
+
+	// Histograms with a lower range can not hold every value
+	short := newHistogram(t, 1, 1e6, 3, nil)
+	if err := short.Merge(whole); err != ErrValueOutOfRange || short.Count() != 0 {
+		t.Errorf("expected error: %v, got:%v (%v values)", ErrValueOutOfRange, err, short.Count())
+	}
+
+	// Merging into a coarser histogram keeps its resolution
+	coarse := newHistogram(t, 1, 3600e6, 2, nil)
+	if err := coarse.Merge(whole); err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+	expected := newHistogram(t, 1, 3600e6, 2, data)
+	if !reflect.DeepEqual(coarse.Buckets(), expected.Buckets()) {
+		t.Errorf("expected merged histogram equal to the one of the data")
+	}
+}
+
+func TestHistogramErrors(t *testing.T) {
+	for _, config := range [][3]int64{{0, 100, 3}, {10, 15, 3}, {1, 100, 0}, {1, 100, 6}} {
+		if _, err := NewHistogram(config[0], config[1], int(config[2])); err != ErrInvalidRange && err != ErrInvalidDigits {
+			t.Errorf("expected invalid configuration error for %v, got:%v", config, err)
+		}
+	}
+
+	h := newHistogram(t, 1, 1000, 3, nil)
+	tests := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{"Percentile", second(h.Percentile(50)), stats.ErrEmptyData},
+		{"Mean", second(h.Mean()), stats.ErrEmptyData},
+		{"StandardDeviation", second(h.StandardDeviation()), stats.ErrEmptyData},
+		{"Min", second(h.Min()), stats.ErrEmptyData},
+		{"Max", second(h.Max()), stats.ErrEmptyData},
+		{"Negative", h.Record(-1), ErrValueOutOfRange},
+		{"High", h.Record(1001), ErrValueOutOfRange},
+		{"Count", h.RecordN(5, 0), ErrInvalidCount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err != tt.expected {
+				t.Errorf("expected error: %v, got:%v", tt.expected, tt.err)
+			}
+		})
+	}
+
+	h.Record(1000)
+	if _, err := h.Percentile(-1); err != stats.ErrInvalidPercentile {
+		t.Errorf("expected error: %v, got:%v", stats.ErrInvalidPercentile, err)
+	}
+}
@@ -0,0 +1,395 @@
+package sketch
+
+import (
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/jaumefe/stats"
+)
+
+const (
+	defaultK = 200
+	// Ratio between the capacities of consecutive levels of a KLL sketch
+	capacityRatio = 2.0 / 3
+	// Version of the binary encoding of the KLL sketches
+	kllVersion = 1
+	// Largest number of levels of a decoded KLL sketch, whose values stand for up to 2^62 values as counts are int64
+	kllMaxLevels = 63
+)
+
+/*
+KLLOptions to set special features of the KLL sketches:
+  - K: accuracy parameter, 200 by default, which bounds the rank error to about 1.3%.
+    Memory grows linearly with it while the error decreases almost inversely
+  - Seed: seed for random number generator of the compactions, a time based one by default
+*/
+type KLLOptions struct {
+	K    int
+	Seed int64
+}
+
+/*
+KLL is a quantile sketch of Karnin, Lang and Liberty, which keeps a sample of the values in levels
+of compactors: values at level h stand for 2^h values, and whenever a level is full it is sorted
+and every other value, starting at a random one, is promoted to the next level. Its size grows
+only with the logarithm of the number of values, and unlike the t-digest its rank error is additive
+and guaranteed: the rank of any estimated quantile, and any estimated rank, is at most RankError
+away from the exact one with probability 99%, whatever the distribution of the values or their order.
+Sketches are fully mergeable with the same guarantee
+*/
+type KLL struct {
+	k      int
+	levels [][]float64
+	size   int
+	limit  int
+	n      int64
+	min    float64
+	max    float64
+	rand   *rand.Rand
+}
+
+/*
+NewKLL returns an empty KLL sketch.
+It returns an error if the accuracy parameter is lower than 0
+*/
+func NewKLL(opts *KLLOptions) (*KLL, error) {
+	if opts == nil {
+		opts = &KLLOptions{}
+	}
+
+	k := opts.K
+	if k < 0 {
+		return nil, ErrInvalidK
+	}
+	if k == 0 {
+		k = defaultK
+	}
+
+	seed := opts.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	s := &KLL{
+		k:    k,
+		min:  math.Inf(1),
+		max:  math.Inf(-1),
+		rand: rand.New(rand.NewSource(seed)),
+	}
+	s.grow()
+	return s, nil
+}
+
+// Returns the capacity of a level of the sketch, which is lower the deeper it is below the top one
+func (s *KLL) capacity(level int) int {
+	depth := len(s.levels) - level - 1
+	return int(math.Ceil(math.Pow(capacityRatio, float64(depth))*float64(s.k))) + 1
+}
+
+// Adds an empty level on top of the sketch, updating the sum of the capacities of every level
+func (s *KLL) grow() {
+	s.levels = append(s.levels, nil)
+	s.limit = 0
+	for h := range s.levels {
+		s.limit += s.capacity(h)
+	}
+}
+
+/*
+Update adds a value to the sketch.
+It returns an error if the value is not finite
+*/
+func (s *KLL) Update(x float64) error {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return ErrInvalidValue
+	}
+
+	s.levels[0] = append(s.levels[0], x)
+	s.size++
+	s.n++
+	s.min = math.Min(s.min, x)
+	s.max = math.Max(s.max, x)
+	if s.size >= s.limit {
+		s.compress()
+	}
+	return nil
+}
+
+// Compacts the full levels of the sketch, from the lowest one, until it is within its capacity
+func (s *KLL) compress() {
+	for h := 0; h < len(s.levels); h++ {
+		if len(s.levels[h]) < s.capacity(h) {
+			continue
+		}
+		if h+1 == len(s.levels) {
+			s.grow()
+		}
+
+		// Every other value is promoted, leaving the largest one behind when there is an odd number of them
+		level := s.levels[h]
+		slices.Sort(level)
+		offset := s.rand.Intn(2)
+		pairs := len(level) / 2
+		for i := 0; i < pairs; i++ {
+			s.levels[h+1] = append(s.levels[h+1], level[2*i+offset])
+		}
+		if len(level)%2 == 1 {
+			s.levels[h] = append(level[:0], level[len(level)-1])
+		} else {
+			s.levels[h] = level[:0]
+		}
+
+		s.size -= pairs
+		if s.size < s.limit {
+			return
+		}
+	}
+}
+
+/*
+Merge adds the values summarized by another sketch into this one, as if they were added to it,
+keeping the same rank error guarantee. The other sketch is not modified.
+It returns an error if the sketches have different accuracy parameters
+*/
+func (s *KLL) Merge(other *KLL) error {
+	if other == nil || other.n == 0 {
+		return nil
+	}
+	if other.k != s.k {
+		return ErrIncompatibleSketch
+	}
+
+	// Levels are copied before appending, in case the other sketch is this one
+	levels := make([][]float64, len(other.levels))
+	for h, level := range other.levels {
+		levels[h] = slices.Clone(level)
+	}
+
+	for len(s.levels) < len(levels) {
+		s.grow()
+	}
+	for h, level := range levels {
+		s.levels[h] = append(s.levels[h], level...)
+		s.size += len(level)
+	}
+	s.n += other.n
+	s.min = math.Min(s.min, other.min)
+	s.max = math.Max(s.max, other.max)
+	for s.size >= s.limit {
+		s.compress()
+	}
+	return nil
+}
+
+// Returns the number of values added to the sketch
+func (s *KLL) Count() int64 {
+	return s.n
+}
+
+// Returns the number of values kept by the sketch, which grows with the logarithm of the number of values
+func (s *KLL) Size() int {
+	return s.size
+}
+
+/*
+RankError returns the normalized rank error of the sketch with probability 99%, following
+the empirical formula of the DataSketches library for its accuracy parameter. It is 0 while
+the sketch keeps every value
+*/
+func (s *KLL) RankError() float64 {
+	if len(s.levels) == 1 {
+		return 0
+	}
+	return 2.296 / math.Pow(float64(s.k), 0.9723)
+}
+
+// Returns the minimum value added to the sketch and an error when it is empty
+func (s *KLL) Min() (float64, error) {
+	if s.n == 0 {
+		return 0, stats.ErrEmptyData
+	}
+	return s.min, nil
+}
+
+// Returns the maximum value added to the sketch and an error when it is empty
+func (s *KLL) Max() (float64, error) {
+	if s.n == 0 {
+		return 0, stats.ErrEmptyData
+	}
+	return s.max, nil
+}
+
+// Value kept by a KLL sketch and the number of values it stands for
+type weighted struct {
+	value  float64
+	weight int64
+}
+
+// Returns the values kept by the sketch sorted, with their weights
+func (s *KLL) sorted() []weighted {
+	res := make([]weighted, 0, s.size)
+	for h, level := range s.levels {
+		for _, v := range level {
+			res = append(res, weighted{value: v, weight: int64(1) << h})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].value < res[j].value
+	})
+	return res
+}
+
+/*
+Quantile returns an estimation of the q-th quantile of the values added to the sketch as the type 1
+quantile of stats.Quantile, the smallest value whose rank is at least q, which is exact while
+the sketch keeps every value.
+It returns an error if the sketch is empty or the quantile is not between 0 and 1
+*/
+func (s *KLL) Quantile(q float64) (float64, error) {
+	if s.n == 0 {
+		return 0, stats.ErrEmptyData
+	}
+	if !(q >= 0 && q <= 1) {
+		return 0, ErrInvalidQuantile
+	}
+	if q == 1 {
+		return s.max, nil
+	}
+
+	rank := quantileRank(q, s.n)
+	cumulative := int64(0)
+	for _, w := range s.sorted() {
+		cumulative += w.weight
+		if cumulative >= rank {
+			return w.value, nil
+		}
+	}
+	return s.max, nil
+}
+
+/*
+Rank returns an estimation of the proportion of values added to the sketch lower than or equal to x,
+which is exact while the sketch keeps every value.
+It returns an error if the sketch is empty or x is NaN
+*/
+func (s *KLL) Rank(x float64) (float64, error) {
+	if s.n == 0 {
+		return 0, stats.ErrEmptyData
+	}
+	if math.IsNaN(x) {
+		return 0, ErrInvalidValue
+	}
+
+	rank := int64(0)
+	for h, level := range s.levels {
+		for _, v := range level {
+			if v <= x {
+				rank += int64(1) << h
+			}
+		}
+	}
+	return float64(rank) / float64(s.n), nil
+}
+
+/*
+MarshalBinary encodes the sketch into bytes, which can be decoded with UnmarshalBinary
+to combine sketches computed elsewhere
+*/
+func (s *KLL) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, 1+4*binary.MaxVarintLen64+16+binary.MaxVarintLen64*len(s.levels)+8*s.size)
+	buf = append(buf, kllVersion)
+	buf = binary.AppendUvarint(buf, uint64(s.k))
+	buf = binary.AppendUvarint(buf, uint64(s.n))
+	buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(s.min))
+	buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(s.max))
+	buf = binary.AppendUvarint(buf, uint64(len(s.levels)))
+	for _, level := range s.levels {
+		buf = binary.AppendUvarint(buf, uint64(len(level)))
+		for _, v := range level {
+			buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(v))
+		}
+	}
+	return buf, nil
+}
+
+/*
+UnmarshalBinary decodes a sketch encoded by MarshalBinary, replacing the content of the sketch.
+Compactions after decoding use a time based seed.
+It returns an error if data is not a valid encoded sketch
+*/
+func (s *KLL) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != kllVersion {
+		return ErrInvalidEncoding
+	}
+	data = data[1:]
+
+	uvarint := func() (uint64, bool) {
+		v, size := binary.Uvarint(data)
+		if size <= 0 {
+			return 0, false
+		}
+		data = data[size:]
+		return v, true
+	}
+	float := func() (float64, bool) {
+		if len(data) < 8 {
+			return 0, false
+		}
+		v := math.Float64frombits(binary.LittleEndian.Uint64(data))
+		data = data[8:]
+		return v, true
+	}
+
+	k, ok1 := uvarint()
+	n, ok2 := uvarint()
+	min, ok3 := float()
+	max, ok4 := float()
+	levels, ok5 := uvarint()
+	if !(ok1 && ok2 && ok3 && ok4 && ok5) || k == 0 || k > math.MaxInt32 || n > math.MaxInt64 || levels == 0 || levels > kllMaxLevels {
+		return ErrInvalidEncoding
+	}
+
+	res := KLL{
+		k:    int(k),
+		n:    int64(n),
+		min:  min,
+		max:  max,
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	for len(res.levels) < int(levels) {
+		res.grow()
+	}
+
+	// Values must lie between the minimum and maximum and their weights add up to the number of values,
+	// which is checked as they are decoded so that their sum does not overflow
+	weight := uint64(0)
+	for h := range res.levels {
+		size, ok := uvarint()
+		if !ok || size > uint64(len(data))/8 || size > (n-weight)>>h {
+			return ErrInvalidEncoding
+		}
+		res.levels[h] = make([]float64, size)
+		for i := range res.levels[h] {
+			v, _ := float()
+			if !(v >= min && v <= max) {
+				return ErrInvalidEncoding
+			}
+			res.levels[h][i] = v
+		}
+		res.size += int(size)
+		weight += size << h
+	}
+	if len(data) != 0 || weight != n {
+		return ErrInvalidEncoding
+	}
+	if n == 0 {
+		res.min, res.max = math.Inf(1), math.Inf(-1)
+	}
+
+	*s = res
+	return nil
+}
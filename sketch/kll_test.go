@@ -0,0 +1,291 @@
+package sketch
+
+import (
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+	"testing/quick"
+
+	"github.com/jaumefe/stats"
+)
+
+// Returns a KLL sketch of data with a given accuracy parameter, failing on errors
+func newKLL(t *testing.T, k int, seed int64, data []float64) *KLL {
+	t.Helper()
+	s, err := NewKLL(&KLLOptions{K: k, Seed: seed})
+	if err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+	for _, v := range data {
+		if err := s.Update(v); err != nil {
+			t.Fatalf("unexpected error received: %v", err)
+		}
+	}
+	return s
+}
+
+// Returns random data of a given size, with repeated values when few distinct ones are drawn
+func randomData(r *rand.Rand, size int) []float64 {
+	data := make([]float64, size)
+	distinct := 1 + r.Intn(1000)
+	for i := range data {
+		switch i % 3 {
+		case 0:
+			data[i] = r.NormFloat64() * 1e3
+		case 1:
+			data[i] = r.ExpFloat64()
+		default:
+			data[i] = float64(r.Intn(distinct))
+		}
+	}
+	r.Shuffle(size, func(i, j int) { data[i], data[j] = data[j], data[i] })
+	return data
+}
+
+// Returns the proportions of sorted data lower than x and lower than or equal to it
+func exactRanks(sorted []float64, x float64) (float64, float64) {
+	lower := sort.SearchFloat64s(sorted, x)
+	upper := sort.Search(len(sorted), func(i int) bool { return sorted[i] > x })
+	return float64(lower) / float64(len(sorted)), float64(upper) / float64(len(sorted))
+}
+
+func TestKLLProperties(t *testing.T) {
+	quantiles := []float64{0, 0.001, 0.01, 0.05, 0.1, 0.25, 0.5, 0.75, 0.9, 0.95, 0.99, 0.999, 1}
+
+	// The rank of every estimated quantile and every estimated rank is within the rank error
+	property := func(seed int64, size uint16, k uint8) bool {
+		r := rand.New(rand.NewSource(seed))
+		data := randomData(r, 1+int(size)%30000)
+		s := newKLL(t, 50+int(k), seed, data)
+		sorted := stats.Sort(data)
+		eps := s.RankError()
+
+		for _, q := range quantiles {
+			res, err := s.Quantile(q)
+			if err != nil {
+				return false
+			}
+
+			// The type 1 percentile has rank q, so the estimation must be close to it in rank
+			exact, _ := stats.PercentileWith(data, 100*q, &stats.Options{Quantile: stats.QuantileType1})
+			lower, upper := exactRanks(sorted, res)
+			if res != exact && (lower > q+eps || upper < q-eps) {
+				t.Logf("quantile %v of %d values: %v, exact %v, ranks [%v, %v], rank error %v", q, len(data), res, exact, lower, upper, eps)
+				return false
+			}
+
+			rank, err := s.Rank(exact)
+			_, expected := exactRanks(sorted, exact)
+			if err != nil || math.Abs(rank-expected) > eps {
+				t.Logf("rank of %v in %d values: %v, exact %v, rank error %v", exact, len(data), rank, expected, eps)
+				return false
+			}
+		}
+		return true
+	}
+
+	config := &quick.Config{MaxCount: 40, Rand: rand.New(rand.NewSource(1))}
+	if err := quick.Check(property, config); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestKLLExact(t *testing.T) {
+	data := []float64{7, 1, 3, 9, 4, 12, 6, 2, 10, 5, 5}
+	s := newKLL(t, 0, 1, data)
+
+	// Sketches keep every value until their first compaction, so they are exact
+	if s.RankError() != 0 || s.Size() != len(data) {
+		t.Errorf("expected exact sketch of %v values, got rank error %v and %v values", len(data), s.RankError(), s.Size())
+	}
+	for _, p := range []float64{0, 9, 10, 30, 50, 54.5, 90, 100} {
+		expected, _ := stats.PercentileWith(data, p, &stats.Options{Quantile: stats.QuantileType1})
+		if res, err := s.Quantile(p / 100); err != nil || res != expected {
+			t.Errorf("expected quantile %v: %v, got:%v (error %v)", p/100, expected, res, err)
+		}
+	}
+
+	for x, expected := range map[float64]float64{0: 0, 1: 1.0 / 11, 5: 6.0 / 11, 5.5: 6.0 / 11, 12: 1, 20: 1} {
+		if res, err := s.Rank(x); err != nil || res != expected {
+			t.Errorf("expected rank of %v: %v, got:%v (error %v)", x, expected, res, err)
+		}
+	}
+}
+
+func TestKLLSize(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	s := newKLL(t, 200, 2, nil)
+	for i := 0; i < 1000000; i++ {
+		s.Update(r.Float64())
+	}
+
+	// Values kept grow logarithmically, within a few times the accuracy parameter
+	if s.Count() != 1000000 || s.Size() > 3*200+64 {
+		t.Errorf("expected at most %v values kept out of %v, got:%v out of %v", 3*200+64, 1000000, s.Size(), s.Count())
+	}
+	if eps := s.RankError(); math.Abs(eps-0.0133) > 0.0005 {
+		t.Errorf("expected rank error: %v, got:%v", 0.0133, eps)
+	}
+	if median, _ := s.Quantile(0.5); math.Abs(median-0.5) > s.RankError() {
+		t.Errorf("expected median close to %v, got:%v", 0.5, median)
+	}
+}
+
+func TestKLLMerge(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	data := randomData(r, 60000)
+	sorted := stats.Sort(data)
+
+	// Sketches of each host are merged into a central one
+	merged := newKLL(t, 200, 3, nil)
+	for i := 0; i < len(data); i += 7500 {
+		if err := merged.Merge(newKLL(t, 200, int64(i+1), data[i:i+7500])); err != nil {
+			t.Fatalf("unexpected error received: %v", err)
+		}
+	}
+
+	min, _ := merged.Min()
+	max, _ := merged.Max()
+	if merged.Count() != int64(len(data)) || min != sorted[0] || max != sorted[len(sorted)-1] {
+		t.Errorf("expected count, minimum and maximum %v, %v and %v, got:%v, %v and %v",
+			len(data), sorted[0], sorted[len(sorted)-1], merged.Count(), min, max)
+	}
+
+	eps := merged.RankError()
+	for _, q := range []float64{0.01, 0.1, 0.5, 0.9, 0.99} {
+		res, _ := merged.Quantile(q)
+		if lower, upper := exactRanks(sorted, res); lower > q+eps || upper < q-eps {
+			t.Errorf("expected merged quantile %v with rank close to it, got:%v with ranks [%v, %v]", q, res, lower, upper)
+		}
+	}
+
+	// Merging a sketch with itself doubles every value
+	s := newKLL(t, 200, 4, data[:1000])
+	s.Merge(s)
+	if rank, _ := s.Rank(sorted[len(sorted)/2]); s.Count() != 2000 || math.IsNaN(rank) {
+		t.Errorf("expected count: %v, got:%v", 2000, s.Count())
+	}
+
+	if err := merged.Merge(newKLL(t, 100, 5, data[:10])); err != ErrIncompatibleSketch {
+		t.Errorf("expected error: %v, got:%v", ErrIncompatibleSketch, err)
+	}
+}
+
+// Returns the encoding of a sketch of n values given its levels, whose values lie between 0 and 1
+func encodeKLL(n uint64, levels [][]float64) []byte {
+	buf := binary.AppendUvarint([]byte{kllVersion}, defaultK)
+	buf = binary.AppendUvarint(buf, n)
+	buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(0))
+	buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(1))
+	buf = binary.AppendUvarint(buf, uint64(len(levels)))
+	for _, level := range levels {
+		buf = binary.AppendUvarint(buf, uint64(len(level)))
+		for _, v := range level {
+			buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(v))
+		}
+	}
+	return buf
+}
+
+func TestKLLEncoding(t *testing.T) {
+	s := newKLL(t, 100, 6, randomData(rand.New(rand.NewSource(6)), 20000))
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+
+	var decoded KLL
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+	if decoded.Count() != s.Count() || decoded.Size() != s.Size() || !reflect.DeepEqual(decoded.levels, s.levels) {
+		t.Errorf("expected decoded sketch equal to the encoded one")
+	}
+	for _, q := range []float64{0, 0.01, 0.5, 0.99, 1} {
+		expected, _ := s.Quantile(q)
+		if res, _ := decoded.Quantile(q); res != expected {
+			t.Errorf("expected decoded quantile %v: %v, got:%v", q, expected, res)
+		}
+	}
+
+	// Decoded sketches keep on accepting values and can be merged
+	if err := decoded.Update(1e6); err != nil {
+		t.Errorf("unexpected error received: %v", err)
+	}
+	if err := decoded.Merge(s); err != nil || decoded.Count() != 2*s.Count()+1 {
+		t.Errorf("expected count: %v, got:%v (error %v)", 2*s.Count()+1, decoded.Count(), err)
+	}
+
+	empty := newKLL(t, 0, 7, nil)
+	data, _ = empty.MarshalBinary()
+	if err := decoded.UnmarshalBinary(data); err != nil || decoded.Count() != 0 {
+		t.Errorf("expected empty sketch, got %v values (error %v)", decoded.Count(), err)
+	}
+
+	small, _ := newKLL(t, 0, 8, []float64{1, 2, 3}).MarshalBinary()
+	for name, data := range map[string][]byte{
+		"empty":     {},
+		"version":   {2},
+		"truncated": small[:len(small)-1],
+		"trailing":  append(small[:len(small):len(small)], 0),
+		"count":     append([]byte{kllVersion, 200, 1, 4}, small[4:]...),
+		// Weights of 4 values at level 62 overflow to 0
+		"overflow": encodeKLL(1, [][]float64{62: {1, 1, 1, 1}, 0: {1}}),
+		// Values at level 63 stand for more values than int64 counts reach
+		"levels": encodeKLL(0, [][]float64{63: {1, 1}}),
+	} {
+		if err := decoded.UnmarshalBinary(data); err != ErrInvalidEncoding {
+			t.Errorf("expected error decoding %v data: %v, got:%v", name, ErrInvalidEncoding, err)
+		}
+	}
+
+	// The highest level holds values standing for 2^62 values
+	if err := decoded.UnmarshalBinary(encodeKLL(1<<62+1, [][]float64{62: {1}, 0: {0}})); err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+	if rank, _ := decoded.Rank(0.5); rank != 1/float64(1<<62+1) {
+		t.Errorf("expected rank: %v, got:%v", 1/float64(1<<62+1), rank)
+	}
+	if median, _ := decoded.Quantile(0.5); median != 1 {
+		t.Errorf("expected median: %v, got:%v", 1, median)
+	}
+}
+
+func TestKLLErrors(t *testing.T) {
+	if _, err := NewKLL(&KLLOptions{K: -1}); err != ErrInvalidK {
+		t.Errorf("expected error: %v, got:%v", ErrInvalidK, err)
+	}
+
+	s, _ := NewKLL(nil)
+	tests := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{"Quantile", second(s.Quantile(0.5)), stats.ErrEmptyData},
+		{"Rank", second(s.Rank(0)), stats.ErrEmptyData},
+		{"Min", second(s.Min()), stats.ErrEmptyData},
+		{"Max", second(s.Max()), stats.ErrEmptyData},
+		{"NaN", s.Update(math.NaN()), ErrInvalidValue},
+		{"Inf", s.Update(math.Inf(-1)), ErrInvalidValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err != tt.expected {
+				t.Errorf("expected error: %v, got:%v", tt.expected, tt.err)
+			}
+		})
+	}
+
+	s.Update(1)
+	if _, err := s.Quantile(-0.1); err != ErrInvalidQuantile {
+		t.Errorf("expected error: %v, got:%v", ErrInvalidQuantile, err)
+	}
+	if _, err := s.Rank(math.NaN()); err != ErrInvalidValue {
+		t.Errorf("expected error: %v, got:%v", ErrInvalidValue, err)
+	}
+}
@@ -0,0 +1,396 @@
+package sketch
+
+import (
+	"encoding/binary"
+	"math"
+	"sort"
+
+	"github.com/jaumefe/stats"
+)
+
+const (
+	defaultCompression = 100
+	// Number of unmerged values, per unit of compression, kept before merging them into the centroids
+	bufferFactor = 5
+	// Version of the binary encoding of the t-digests
+	tdigestVersion = 1
+)
+
+// Centroid of a t-digest: the mean of some values and their total weight
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+/*
+TDigest is a merging t-digest of Dunning and Ertl, which summarizes a stream of values as a sorted list
+of centroids whose size is bounded by the compression, so that its memory does not grow with the number
+of values. Centroids near the tails hold fewer values than those in the middle, following the k2 scale
+function, so the rank errors of quantiles and CDF values are bounded relatively to the distance to the
+closest tail: a few percent of q for the q-th quantile, down to single values at the extremes.
+Values are buffered and merged into the centroids in batches, so queries may reorganize the digest,
+which is not safe for concurrent use.
+The zero value is not usable, digests must be created with NewTDigest
+*/
+type TDigest struct {
+	compression float64
+	centroids   []centroid
+	buffer      []centroid
+	weight      float64
+	min         float64
+	max         float64
+}
+
+/*
+NewTDigest returns an empty t-digest given its compression, the approximate maximum number of centroids,
+which trades memory for accuracy: 100 by default, when compression is 0.
+It returns an error if the compression is not a finite value greater than 0
+*/
+func NewTDigest(compression float64) (*TDigest, error) {
+	if compression == 0 {
+		compression = defaultCompression
+	}
+	if !(compression > 0) || math.IsInf(compression, 1) {
+		return nil, ErrInvalidCompression
+	}
+
+	return &TDigest{
+		compression: compression,
+		min:         math.Inf(1),
+		max:         math.Inf(-1),
+	}, nil
+}
+
+/*
+Add adds a value to the digest.
+It returns an error if the value is not finite
+*/
+func (td *TDigest) Add(x float64) error {
+	return td.AddWeighted(x, 1)
+}
+
+/*
+AddWeighted adds a value with a given weight to the digest, so adding a value with weight 2
+is equivalent to adding it twice.
+It returns an error if the value is not finite or the weight is not a finite value greater than 0
+*/
+func (td *TDigest) AddWeighted(x, w float64) error {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return ErrInvalidValue
+	}
+	if !(w > 0) || math.IsInf(w, 1) {
+		return stats.ErrInvalidWeight
+	}
+
+	td.buffer = append(td.buffer, centroid{mean: x, weight: w})
+	td.weight += w
+	td.min = math.Min(td.min, x)
+	td.max = math.Max(td.max, x)
+	if len(td.buffer) >= bufferFactor*int(math.Ceil(td.compression)) {
+		td.compress()
+	}
+	return nil
+}
+
+/*
+Merge adds the values summarized by another digest into this one, as if they were added to it.
+The other digest is not modified
+*/
+func (td *TDigest) Merge(other *TDigest) {
+	if other == nil || other.weight == 0 {
+		return
+	}
+
+	// Slices are taken before appending, in case the other digest is this one
+	centroids, buffer := other.centroids, other.buffer
+	td.buffer = append(td.buffer, centroids...)
+	td.buffer = append(td.buffer, buffer...)
+	td.weight += other.weight
+	td.min = math.Min(td.min, other.min)
+	td.max = math.Max(td.max, other.max)
+	td.compress()
+}
+
+// Returns the value of the k2 scale function of the digest at quantile q
+func (td *TDigest) scale(q float64) float64 {
+	z := 4*math.Log(math.Max(1, td.weight/td.compression)) + 24
+	return td.compression / z * math.Log(q/(1-q))
+}
+
+/*
+Merges the buffered values into the centroids: sorted by their mean, consecutive centroids
+are merged while the merged one spans at most one unit of the scale function
+*/
+func (td *TDigest) compress() {
+	if len(td.buffer) == 0 {
+		return
+	}
+
+	all := append(td.centroids, td.buffer...)
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].mean < all[j].mean
+	})
+
+	merged := make([]centroid, 0, len(all))
+	cur := all[0]
+	before := 0.0
+	for _, c := range all[1:] {
+		left := td.scale(before / td.weight)
+		right := td.scale(math.Min(1, (before+cur.weight+c.weight)/td.weight))
+		if right-left <= 1 {
+			cur.weight += c.weight
+			cur.mean += (c.mean - cur.mean) * c.weight / cur.weight
+			continue
+		}
+
+		merged = append(merged, cur)
+		before += cur.weight
+		cur = c
+	}
+	merged = append(merged, cur)
+
+	td.centroids = merged
+	td.buffer = td.buffer[:0]
+}
+
+// Returns the sum of the weights of the values added to the digest
+func (td *TDigest) Weight() float64 {
+	return td.weight
+}
+
+// Returns the number of centroids of the digest, which is bounded by its compression
+func (td *TDigest) Centroids() int {
+	td.compress()
+	return len(td.centroids)
+}
+
+// Returns the minimum value added to the digest and an error when it is empty
+func (td *TDigest) Min() (float64, error) {
+	if td.weight == 0 {
+		return 0, stats.ErrEmptyData
+	}
+	return td.min, nil
+}
+
+// Returns the maximum value added to the digest and an error when it is empty
+func (td *TDigest) Max() (float64, error) {
+	if td.weight == 0 {
+		return 0, stats.ErrEmptyData
+	}
+	return td.max, nil
+}
+
+/*
+Quantile returns an estimation of the q-th quantile of the values added to the digest, interpolating
+between the centroids, which are placed at the middle of the values they summarize, and the minimum
+and maximum values. Centroids of a single value are not interpolated, so the quantiles of small
+amounts of data are their values, as type 1 quantiles.
+It returns an error if the digest is empty or the quantile is not between 0 and 1
+*/
+func (td *TDigest) Quantile(q float64) (float64, error) {
+	if td.weight == 0 {
+		return 0, stats.ErrEmptyData
+	}
+	if !(q >= 0 && q <= 1) {
+		return 0, ErrInvalidQuantile
+	}
+
+	td.compress()
+	c := td.centroids
+	n := len(c)
+	total := td.weight
+	if n == 1 {
+		return td.min + q*(td.max-td.min), nil
+	}
+
+	index := q * total
+	if index < 1 {
+		return td.min, nil
+	}
+	// The values of the tail centroids are placed between the extreme values and their mean,
+	// as long as they hold more than the extreme value itself and the one at their mean
+	if c[0].weight > 2 && index < c[0].weight/2 {
+		return td.min + (index-1)/(c[0].weight/2-1)*(c[0].mean-td.min), nil
+	}
+	if index > total-1 {
+		return td.max, nil
+	}
+	if c[n-1].weight > 2 && total-index <= c[n-1].weight/2 {
+		return td.max - (total-index-1)/(c[n-1].weight/2-1)*(td.max-c[n-1].mean), nil
+	}
+
+	before := c[0].weight / 2
+	for i := 0; i < n-1; i++ {
+		dw := (c[i].weight + c[i+1].weight) / 2
+		if before+dw <= index {
+			before += dw
+			continue
+		}
+
+		// Single values are not spread around their centroid
+		leftUnit, rightUnit := 0.0, 0.0
+		if c[i].weight == 1 {
+			if index-before < 0.5 {
+				return c[i].mean, nil
+			}
+			leftUnit = 0.5
+		}
+		if c[i+1].weight == 1 {
+			if before+dw-index <= 0.5 {
+				return c[i+1].mean, nil
+			}
+			rightUnit = 0.5
+		}
+
+		z1 := index - before - leftUnit
+		z2 := before + dw - index - rightUnit
+		return weightedAverage(c[i].mean, z2, c[i+1].mean, z1), nil
+	}
+	return td.max, nil
+}
+
+// Returns the average of two values given their weights, clamped between them against rounding errors
+func weightedAverage(x1, w1, x2, w2 float64) float64 {
+	if x1 > x2 {
+		x1, w1, x2, w2 = x2, w2, x1, w1
+	}
+	return math.Max(x1, math.Min(x2, (x1*w1+x2*w2)/(w1+w2)))
+}
+
+/*
+CDF returns an estimation of the proportion of values added to the digest lower than x, counting half
+of those equal to it, interpolating as Quantile does.
+It returns an error if the digest is empty or x is NaN
+*/
+func (td *TDigest) CDF(x float64) (float64, error) {
+	if td.weight == 0 {
+		return 0, stats.ErrEmptyData
+	}
+	if math.IsNaN(x) {
+		return 0, ErrInvalidValue
+	}
+
+	td.compress()
+	c := td.centroids
+	n := len(c)
+	total := td.weight
+	switch {
+	case x < td.min:
+		return 0, nil
+	case x > td.max:
+		return 1, nil
+	case n == 1:
+		if td.max == td.min {
+			return 0.5, nil
+		}
+		return (x - td.min) / (td.max - td.min), nil
+	}
+
+	if x < c[0].mean {
+		if x == td.min {
+			return 0.5 / total, nil
+		}
+		if c[0].weight <= 2 {
+			return c[0].weight / 2 / total, nil
+		}
+		return (1 + (x-td.min)/(c[0].mean-td.min)*(c[0].weight/2-1)) / total, nil
+	}
+	if x > c[n-1].mean {
+		if x == td.max {
+			return 1 - 0.5/total, nil
+		}
+		if c[n-1].weight <= 2 {
+			return 1 - c[n-1].weight/2/total, nil
+		}
+		return 1 - (1+(td.max-x)/(td.max-c[n-1].mean)*(c[n-1].weight/2-1))/total, nil
+	}
+
+	before := 0.0
+	for i := 0; i < n; i++ {
+		if c[i].mean == x {
+			equal := 0.0
+			for ; i < n && c[i].mean == x; i++ {
+				equal += c[i].weight
+			}
+			return (before + equal/2) / total, nil
+		}
+
+		if x < c[i+1].mean {
+			// Single values are not spread around their centroid
+			leftExcluded, rightExcluded := 0.0, 0.0
+			if c[i].weight == 1 {
+				leftExcluded = 0.5
+			}
+			if c[i+1].weight == 1 {
+				rightExcluded = 0.5
+			}
+
+			dw := (c[i].weight+c[i+1].weight)/2 - leftExcluded - rightExcluded
+			base := before + c[i].weight/2 + leftExcluded
+			return (base + dw*(x-c[i].mean)/(c[i+1].mean-c[i].mean)) / total, nil
+		}
+		before += c[i].weight
+	}
+	return 1, nil
+}
+
+/*
+MarshalBinary encodes the digest into bytes, which can be decoded with UnmarshalBinary
+to combine digests computed elsewhere
+*/
+func (td *TDigest) MarshalBinary() ([]byte, error) {
+	td.compress()
+
+	buf := make([]byte, 0, 1+3*8+binary.MaxVarintLen64+16*len(td.centroids))
+	buf = append(buf, tdigestVersion)
+	for _, v := range []float64{td.compression, td.min, td.max} {
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(v))
+	}
+	buf = binary.AppendUvarint(buf, uint64(len(td.centroids)))
+	for _, c := range td.centroids {
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(c.mean))
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(c.weight))
+	}
+	return buf, nil
+}
+
+/*
+UnmarshalBinary decodes a digest encoded by MarshalBinary, replacing the content of the digest.
+It returns an error if data is not a valid encoded digest
+*/
+func (td *TDigest) UnmarshalBinary(data []byte) error {
+	if len(data) < 1+3*8 || data[0] != tdigestVersion {
+		return ErrInvalidEncoding
+	}
+
+	float := func(i int) float64 {
+		return math.Float64frombits(binary.LittleEndian.Uint64(data[i:]))
+	}
+	compression, min, max := float(1), float(9), float(17)
+	n, size := binary.Uvarint(data[25:])
+	if size <= 0 || n > uint64(len(data)) || uint64(len(data)-25-size) != 16*n {
+		return ErrInvalidEncoding
+	}
+	if !(compression > 0) || math.IsInf(compression, 1) {
+		return ErrInvalidEncoding
+	}
+
+	res := TDigest{compression: compression, min: min, max: max, centroids: make([]centroid, n)}
+	offset := 25 + size
+	for i := range res.centroids {
+		c := centroid{mean: float(offset), weight: float(offset + 8)}
+		if math.IsNaN(c.mean) || !(c.weight > 0) || c.mean < min || c.mean > max ||
+			(i > 0 && c.mean < res.centroids[i-1].mean) {
+			return ErrInvalidEncoding
+		}
+		res.centroids[i] = c
+		res.weight += c.weight
+		offset += 16
+	}
+	if n == 0 {
+		res.min, res.max = math.Inf(1), math.Inf(-1)
+	}
+
+	*td = res
+	return nil
+}
@@ -0,0 +1,281 @@
+package sketch
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/jaumefe/stats"
+)
+
+// Returns the proportion of sorted data lower than x, counting half of the values equal to it
+func exactCDF(sorted []float64, x float64) float64 {
+	lower := sort.SearchFloat64s(sorted, x)
+	upper := sort.Search(len(sorted), func(i int) bool { return sorted[i] > x })
+	return (float64(lower) + float64(upper-lower)/2) / float64(len(sorted))
+}
+
+// Returns a digest of data with a given compression, failing on errors
+func newDigest(t *testing.T, compression float64, data []float64) *TDigest {
+	t.Helper()
+	td, err := NewTDigest(compression)
+	if err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+	for _, v := range data {
+		if err := td.Add(v); err != nil {
+			t.Fatalf("unexpected error received: %v", err)
+		}
+	}
+	return td
+}
+
+func TestTDigestSmallData(t *testing.T) {
+	data := []float64{7, 1, 3, 9, 4, 12, 6, 2, 10, 5}
+	td := newDigest(t, 0, data)
+
+	// Few values are kept as they are, so their quantiles are exact out of the steps of the distribution
+	if n := td.Centroids(); n != len(data) {
+		t.Errorf("expected centroids: %v, got:%v", len(data), n)
+	}
+	for _, q := range []float64{0, 0.05, 0.27, 0.33, 0.55, 0.78, 0.99, 1} {
+		expected, _ := stats.PercentileWith(data, 100*q, &stats.Options{Quantile: stats.QuantileType1})
+		if res, err := td.Quantile(q); err != nil || res != expected {
+			t.Errorf("expected quantile %v: %v, got:%v (error %v)", q, expected, res, err)
+		}
+	}
+
+	sorted := stats.Sort(data)
+	for _, x := range []float64{0, 1, 2.5, 6, 9.9, 12, 13} {
+		if res, err := td.CDF(x); err != nil || math.Abs(res-exactCDF(sorted, x)) > 0.05+1e-12 {
+			t.Errorf("expected CDF at %v: %v, got:%v (error %v)", x, exactCDF(sorted, x), res, err)
+		}
+	}
+
+	min, _ := td.Min()
+	max, _ := td.Max()
+	if min != 1 || max != 12 || td.Weight() != 10 {
+		t.Errorf("expected minimum, maximum and weight 1, 12 and 10, got:%v, %v and %v", min, max, td.Weight())
+	}
+}
+
+func TestTDigestLightTails(t *testing.T) {
+	// Tail centroids lighter than 2 values lie between the values of the digest
+	withTails := func(left, right float64) *TDigest {
+		td := newDigest(t, 0, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+		td.AddWeighted(0, left)
+		td.AddWeighted(11, right)
+		return td
+	}
+	// Tail centroids of merged digests may also hold less than 2 values away from the extreme ones
+	merged := &TDigest{
+		compression: defaultCompression,
+		centroids:   []centroid{{mean: 1, weight: 1.5}, {mean: 5.5, weight: 8}, {mean: 10, weight: 1.5}},
+		weight:      11,
+		min:         0,
+		max:         11,
+	}
+
+	tests := []struct {
+		name string
+		td   *TDigest
+		last float64
+	}{
+		{"Weight 2", withTails(2, 2), 11},
+		{"Fractional weight", withTails(1.5, 1.5), math.NaN()},
+		{"Light left tail", withTails(1.5, 1), math.NaN()},
+		{"Merged", merged, math.NaN()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prev := math.Inf(-1)
+			for i := 0; i <= 100; i++ {
+				q := float64(i) / 100
+				res, err := tt.td.Quantile(q)
+				if err != nil || math.IsNaN(res) || res < prev || res < 0 || res > 11 {
+					t.Errorf("expected non decreasing quantile %v between 0 and 11 after %v, got:%v (error %v)", q, prev, res, err)
+				}
+				prev = res
+			}
+
+			// The quantile before the maximum is the mean of a tail centroid of 2 values
+			total := tt.td.Weight()
+			if res, _ := tt.td.Quantile((total - 1) / total); !math.IsNaN(tt.last) && res != tt.last {
+				t.Errorf("expected quantile %v: %v, got:%v", (total-1)/total, tt.last, res)
+			}
+
+			prev = 0
+			for x := -0.5; x <= 11.5; x += 0.125 {
+				res, err := tt.td.CDF(x)
+				if err != nil || math.IsNaN(res) || res < prev || res > 1 {
+					t.Errorf("expected non decreasing CDF at %v between 0 and 1 after %v, got:%v (error %v)", x, prev, res, err)
+				}
+				prev = res
+			}
+		})
+	}
+}
+
+func TestTDigestAccuracy(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	data := make([]float64, 100000)
+	for i := range data {
+		data[i] = r.ExpFloat64()
+	}
+	td := newDigest(t, 100, data)
+	sorted := stats.Sort(data)
+
+	if n := td.Centroids(); n > 100 {
+		t.Errorf("expected at most %v centroids, got:%v", 100, n)
+	}
+
+	// Rank errors are relative to the distance to the closest tail
+	for _, q := range []float64{0.00001, 0.0001, 0.001, 0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99, 0.999, 0.9999} {
+		res, err := td.Quantile(q)
+		if err != nil {
+			t.Fatalf("unexpected error received: %v", err)
+		}
+		if rankErr := math.Abs(exactCDF(sorted, res) - q); rankErr > 0.05*math.Min(q, 1-q)+2/float64(len(data)) {
+			t.Errorf("expected quantile %v close to %v, got:%v (rank error %v)", q, sorted[int(q*float64(len(data)))], res, rankErr)
+		}
+
+		x := sorted[int(q*float64(len(data)))]
+		cdf, err := td.CDF(x)
+		if err != nil || math.Abs(cdf-exactCDF(sorted, x)) > 0.05*math.Min(q, 1-q)+2/float64(len(data)) {
+			t.Errorf("expected CDF at %v: %v, got:%v (error %v)", x, exactCDF(sorted, x), cdf, err)
+		}
+	}
+}
+
+func TestTDigestMerge(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	data := make([]float64, 50000)
+	for i := range data {
+		data[i] = r.NormFloat64()
+	}
+
+	// Digests of each host are merged into a central one
+	merged, _ := NewTDigest(100)
+	for i := 0; i < len(data); i += 5000 {
+		merged.Merge(newDigest(t, 100, data[i:i+5000]))
+	}
+	sorted := stats.Sort(data)
+
+	if merged.Weight() != float64(len(data)) {
+		t.Errorf("expected weight: %v, got:%v", len(data), merged.Weight())
+	}
+	min, _ := merged.Min()
+	max, _ := merged.Max()
+	if min != sorted[0] || max != sorted[len(sorted)-1] {
+		t.Errorf("expected minimum and maximum %v and %v, got:%v and %v", sorted[0], sorted[len(sorted)-1], min, max)
+	}
+
+	for _, q := range []float64{0.001, 0.01, 0.5, 0.99, 0.999} {
+		res, _ := merged.Quantile(q)
+		if rankErr := math.Abs(exactCDF(sorted, res) - q); rankErr > 0.05*math.Min(q, 1-q)+2/float64(len(data)) {
+			t.Errorf("expected merged quantile %v close to %v, got:%v (rank error %v)", q, sorted[int(q*float64(len(data)))], res, rankErr)
+		}
+	}
+
+	// Merging a digest with itself doubles every value
+	td := newDigest(t, 100, data[:1000])
+	median, _ := td.Quantile(0.5)
+	td.Merge(td)
+	if doubled, _ := td.Quantile(0.5); td.Weight() != 2000 || math.Abs(doubled-median) > 0.05 {
+		t.Errorf("expected weight and median %v and %v, got:%v and %v", 2000, median, td.Weight(), doubled)
+	}
+}
+
+func TestTDigestEncoding(t *testing.T) {
+	r := rand.New(rand.NewSource(9))
+	td, _ := NewTDigest(50)
+	for i := 0; i < 10000; i++ {
+		td.AddWeighted(r.NormFloat64(), 1+r.Float64())
+	}
+
+	data, err := td.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+
+	var decoded TDigest
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+	if math.Abs(decoded.Weight()-td.Weight()) > 1e-9 || decoded.Centroids() != td.Centroids() {
+		t.Errorf("expected weight and centroids %v and %v, got:%v and %v", td.Weight(), td.Centroids(), decoded.Weight(), decoded.Centroids())
+	}
+	for _, q := range []float64{0, 0.01, 0.5, 0.99, 1} {
+		expected, _ := td.Quantile(q)
+		if res, _ := decoded.Quantile(q); math.Abs(res-expected) > 1e-9 {
+			t.Errorf("expected decoded quantile %v: %v, got:%v", q, expected, res)
+		}
+	}
+
+	// Decoded digests keep on accepting values
+	if err := decoded.Add(100); err != nil {
+		t.Errorf("unexpected error received: %v", err)
+	}
+	if max, _ := decoded.Max(); max != 100 {
+		t.Errorf("expected maximum: %v, got:%v", 100, max)
+	}
+
+	empty, _ := NewTDigest(0)
+	data, _ = empty.MarshalBinary()
+	if err := decoded.UnmarshalBinary(data); err != nil || decoded.Weight() != 0 {
+		t.Errorf("expected empty digest, got weight %v (error %v)", decoded.Weight(), err)
+	}
+
+	for name, data := range map[string][]byte{
+		"empty":     {},
+		"version":   {2},
+		"truncated": data[:len(data)-1],
+		"trailing":  append(data, 0),
+	} {
+		if err := decoded.UnmarshalBinary(data); err != ErrInvalidEncoding {
+			t.Errorf("expected error decoding %v data: %v, got:%v", name, ErrInvalidEncoding, err)
+		}
+	}
+}
+
+func TestTDigestErrors(t *testing.T) {
+	if _, err := NewTDigest(-1); err != ErrInvalidCompression {
+		t.Errorf("expected error: %v, got:%v", ErrInvalidCompression, err)
+	}
+
+	td, _ := NewTDigest(0)
+	tests := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{"Quantile", second(td.Quantile(0.5)), stats.ErrEmptyData},
+		{"CDF", second(td.CDF(0)), stats.ErrEmptyData},
+		{"Min", second(td.Min()), stats.ErrEmptyData},
+		{"NaN", td.Add(math.NaN()), ErrInvalidValue},
+		{"Inf", td.Add(math.Inf(1)), ErrInvalidValue},
+		{"Weight", td.AddWeighted(1, 0), stats.ErrInvalidWeight},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err != tt.expected {
+				t.Errorf("expected error: %v, got:%v", tt.expected, tt.err)
+			}
+		})
+	}
+
+	td.Add(1)
+	if _, err := td.Quantile(1.5); err != ErrInvalidQuantile {
+		t.Errorf("expected error: %v, got:%v", ErrInvalidQuantile, err)
+	}
+	if _, err := td.CDF(math.NaN()); err != ErrInvalidValue {
+		t.Errorf("expected error: %v, got:%v", ErrInvalidValue, err)
+	}
+}
+
+func second[T any](_ T, err error) error {
+	return err
+}
@@ -10,17 +10,25 @@ Mean computes the mean value of a []float64 data input.
 It returns an error if the data is empty
 */
 func Mean(data []float64) (float64, error) {
-	n := len(data)
-	if len(data) == 0 {
-		return 0, ErrEmptyData
+	return MeanWith(data, nil)
+}
+
+/*
+MeanWith computes the mean value of a []float64 data input with some options.
+It returns an error if the data is empty or contains NaN values when they are not allowed
+*/
+func MeanWith(data []float64, opts *Options) (float64, error) {
+	data, err := opts.filter(data)
+	if err != nil {
+		return 0, err
 	}
 
-	sum := 0.0
-	for i := 0; i < n; i++ {
-		sum += data[i]
+	n := len(data)
+	if n == 0 {
+		return 0, ErrEmptyData
 	}
 
-	return sum / float64(n), nil
+	return sum(data, opts.summation()) / float64(n), nil
 }
 
 /*
@@ -29,17 +37,37 @@ Data input does not need to be sorted.
 It returns an error if the data is empty
 */
 func Median(data []float64) (float64, error) {
+	return MedianWith(data, nil)
+}
+
+/*
+MedianWith provides the median value of a []float64 data input with some options.
+Data input does not need to be sorted.
+It returns an error if the data is empty or contains NaN values when they are not allowed
+*/
+func MedianWith(data []float64, opts *Options) (float64, error) {
+	data, nan, err := opts.clean(data)
+	if err != nil {
+		return 0, err
+	}
+
 	n := len(data)
 	if n == 0 {
 		return 0, ErrEmptyData
 	}
 
-	sorted := Sort(data)
+	if nan {
+		return math.NaN(), nil
+	}
+
+	// The middle values are selected in linear time out of a copy instead of sorting it
+	work := append([]float64(nil), data...)
 	if n%2 == 1 {
-		return sorted[n/2], nil
+		return selectK(work, n/2), nil
 	}
 
-	return (sorted[n/2-1] + sorted[n/2]) / 2, nil
+	lower := selectK(work, n/2-1)
+	return (lower + slices.Min(work[n/2:])) / 2, nil
 }
 
 /*
@@ -47,11 +75,28 @@ Mode provides the mode value of a []float64 data input.
 It returns an error if the data is empty
 */
 func Mode(data []float64) (float64, error) {
+	return ModeWith(data, nil)
+}
+
+/*
+ModeWith provides the mode value of a []float64 data input with some options.
+It returns an error if the data is empty or contains NaN values when they are not allowed
+*/
+func ModeWith(data []float64, opts *Options) (float64, error) {
+	data, nan, err := opts.clean(data)
+	if err != nil {
+		return 0, err
+	}
+
 	n := len(data)
 	if n == 0 {
 		return 0, ErrEmptyData
 	}
 
+	if nan {
+		return math.NaN(), nil
+	}
+
 	modeValues := make(map[float64]int, 0)
 	for _, d := range data {
 		if _, ok := modeValues[d]; !ok {
@@ -76,16 +121,31 @@ Variance computes the variance value of a []float64 data input.
 It returns an error if the data is empty
 */
 func Variance(data []float64) (float64, error) {
-	mean, err := Mean(data)
+	return VarianceWith(data, nil)
+}
+
+/*
+VarianceWith computes the variance value of a []float64 data input with some options.
+It returns an error if the data is empty, contains NaN values when they are not allowed
+or has a single value for the sample estimator
+*/
+func VarianceWith(data []float64, opts *Options) (float64, error) {
+	data, err := opts.filter(data)
 	if err != nil {
 		return 0, err
 	}
 
-	sum := 0.0
 	n := len(data)
+	if n == 0 {
+		return 0, ErrEmptyData
+	}
 
-	for _, v := range data {
-		sum += math.Pow((v - mean), 2)
+	sum := centralSum(data, 2, opts.summation())
+	if opts.estimator() == SampleEstimator {
+		if n < 2 {
+			return 0, ErrNotEnoughData
+		}
+		return sum / float64(n-1), nil
 	}
 	return sum / float64(n), nil
 }
@@ -95,7 +155,16 @@ StandardDeviation computes the standard deviation value of a []float64 data inpu
 It returns an error if the data is empty
 */
 func StandardDeviation(data []float64) (float64, error) {
-	variance, err := Variance(data)
+	return StandardDeviationWith(data, nil)
+}
+
+/*
+StandardDeviationWith computes the standard deviation value of a []float64 data input with some options.
+It returns an error if the data is empty, contains NaN values when they are not allowed
+or has a single value for the sample estimator
+*/
+func StandardDeviationWith(data []float64, opts *Options) (float64, error) {
+	variance, err := VarianceWith(data, opts)
 	if err != nil {
 		return 0, err
 	}
@@ -109,11 +178,28 @@ Max returns the maximum value of a []float64 data input.
 It returns an error if the data is empty
 */
 func Max(data []float64) (float64, error) {
+	return MaxWith(data, nil)
+}
+
+/*
+MaxWith returns the maximum value of a []float64 data input with some options.
+It returns an error if the data is empty or contains NaN values when they are not allowed
+*/
+func MaxWith(data []float64, opts *Options) (float64, error) {
+	data, nan, err := opts.clean(data)
+	if err != nil {
+		return 0, err
+	}
+
 	n := len(data)
 	if n == 0 {
 		return 0, ErrEmptyData
 	}
 
+	if nan {
+		return math.NaN(), nil
+	}
+
 	max := data[0]
 	for _, d := range data {
 		if d > max {
@@ -129,11 +215,28 @@ Min returns the minimum value of a []float64 data input.
 It returns an error if the data is empty
 */
 func Min(data []float64) (float64, error) {
+	return MinWith(data, nil)
+}
+
+/*
+MinWith returns the minimum value of a []float64 data input with some options.
+It returns an error if the data is empty or contains NaN values when they are not allowed
+*/
+func MinWith(data []float64, opts *Options) (float64, error) {
+	data, nan, err := opts.clean(data)
+	if err != nil {
+		return 0, err
+	}
+
 	n := len(data)
 	if n == 0 {
 		return 0, ErrEmptyData
 	}
 
+	if nan {
+		return math.NaN(), nil
+	}
+
 	min := data[0]
 	for _, d := range data {
 		if d < min {
@@ -149,12 +252,21 @@ Range computes the difference between the maximum and the minimum value of a []f
 It returns an error if the data is empty
 */
 func Range(data []float64) (float64, error) {
-	max, err := Max(data)
+	return RangeWith(data, nil)
+}
+
+/*
+RangeWith computes the difference between the maximum and the minimum value of a []float64 data input
+with some options.
+It returns an error if the data is empty or contains NaN values when they are not allowed
+*/
+func RangeWith(data []float64, opts *Options) (float64, error) {
+	max, err := MaxWith(data, opts)
 	if err != nil {
 		return 0, err
 	}
 
-	min, err := Min(data)
+	min, err := MinWith(data, opts)
 	if err != nil {
 		return 0, err
 	}
@@ -166,12 +278,52 @@ func Range(data []float64) (float64, error) {
 Sum computes the sum of all the elements of a []float64 data input.
 */
 func Sum(data []float64) float64 {
-	sum := 0.0
-	for _, d := range data {
-		sum += d
+	res, _ := SumWith(data, nil)
+	return res
+}
+
+/*
+SumWith computes the sum of all the elements of a []float64 data input with some options.
+It returns an error if the data contains NaN values when they are not allowed
+*/
+func SumWith(data []float64, opts *Options) (float64, error) {
+	data, err := opts.filter(data)
+	if err != nil {
+		return 0, err
+	}
+
+	return sum(data, opts.summation()), nil
+}
+
+// Returns the sum of the values of data with a summation method, adding them directly when naive
+func sum(data []float64, s Summation) float64 {
+	if s == NaiveSummation {
+		res := 0.0
+		for _, v := range data {
+			res += v
+		}
+		return res
 	}
 
-	return sum
+	return s.Sum(len(data), func(i int) float64 {
+		return data[i]
+	})
+}
+
+// Returns the sum of the deviations from the mean raised to a power of a non empty data input with a summation method
+func centralSum(data []float64, power float64, s Summation) float64 {
+	mean := sum(data, s) / float64(len(data))
+	if s == NaiveSummation {
+		res := 0.0
+		for _, v := range data {
+			res += math.Pow(v-mean, power)
+		}
+		return res
+	}
+
+	return s.Sum(len(data), func(i int) float64 {
+		return math.Pow((data[i] - mean), power)
+	})
 }
 
 /*
@@ -179,14 +331,41 @@ Sort returns a sorted copy of the input []float64 data.
 It returns an empty slice if the input data is empty
 */
 func Sort(data []float64) []float64 {
+	sorted, _ := SortWith(data, nil)
+	return sorted
+}
+
+/*
+SortWith returns a sorted copy of the input []float64 data with some options.
+It returns an empty slice if the input data is empty
+and an error if it contains NaN values when they are not allowed
+*/
+func SortWith(data []float64, opts *Options) ([]float64, error) {
+	data, nan, err := opts.clean(data)
+	if err != nil {
+		return nil, err
+	}
+
 	n := len(data)
 	if n == 0 {
-		return nil
+		return nil, nil
 	}
 	sortData := make([]float64, len(data))
 	copy(sortData, data)
 	slices.Sort(sortData)
-	return sortData
+
+	if nan {
+		// NaN values are sorted first, so they are moved to the end
+		first := 0
+		for first < n && math.IsNaN(sortData[first]) {
+			first++
+		}
+		copy(sortData, sortData[first:])
+		for i := n - first; i < n; i++ {
+			sortData[i] = math.NaN()
+		}
+	}
+	return sortData, nil
 }
 
 /*
@@ -194,18 +373,28 @@ ReverseSort returns a sorted copy from maximum to minimum value of the input []f
 It returns an empty slice if the input data is empty
 */
 func ReverseSort(data []float64) []float64 {
-	n := len(data)
-	if n == 0 {
-		return nil
-	}
+	reversed, _ := ReverseSortWith(data, nil)
+	return reversed
+}
 
-	reversed := Sort(data)
+/*
+ReverseSortWith returns a sorted copy from maximum to minimum value of the input []float64 data with some options.
+NaN values, when propagated, are placed first.
+It returns an empty slice if the input data is empty
+and an error if it contains NaN values when they are not allowed
+*/
+func ReverseSortWith(data []float64, opts *Options) ([]float64, error) {
+	reversed, err := SortWith(data, opts)
+	if err != nil {
+		return nil, err
+	}
 
+	n := len(reversed)
 	for i := 0; i < n/2; i++ {
 		reversed[i], reversed[n-1-i] = reversed[n-1-i], reversed[i]
 	}
 
-	return reversed
+	return reversed, nil
 }
 
 /*
@@ -213,12 +402,27 @@ Normalize computes the normalized values of a []float64 data input.
 It returns an error whether the standard deviation is null and / or the data is empty
 */
 func Normalize(data []float64) ([]float64, error) {
-	mean, err := Mean(data)
+	return NormalizeWith(data, nil)
+}
+
+/*
+NormalizeWith computes the normalized values of a []float64 data input with some options,
+dividing by the standard deviation of the chosen estimator.
+It returns an error whether the standard deviation is null and / or the data is empty,
+contains NaN values when they are not allowed or has a single value for the sample estimator
+*/
+func NormalizeWith(data []float64, opts *Options) ([]float64, error) {
+	data, err := opts.filter(data)
 	if err != nil {
 		return nil, err
 	}
 
-	std, err := StandardDeviation(data)
+	mean, err := MeanWith(data, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	std, err := StandardDeviationWith(data, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -241,6 +445,20 @@ Factor must be different from 0.
 It returns an error if the data is empty or the factor is null
 */
 func Scale(data []float64, factor float64) ([]float64, error) {
+	return ScaleWith(data, factor, nil)
+}
+
+/*
+ScaleWith computes the scaled values of a []float64 data input given a factor with some options.
+Factor must be different from 0.
+It returns an error if the data is empty, the factor is null or data contains NaN values when they are not allowed
+*/
+func ScaleWith(data []float64, factor float64, opts *Options) ([]float64, error) {
+	data, err := opts.filter(data)
+	if err != nil {
+		return nil, err
+	}
+
 	n := len(data)
 	if n == 0 {
 		return nil, ErrEmptyData
@@ -262,17 +480,38 @@ Equals returns a boolean value whether two []float64 data inputs are equal withi
 Epsilon value can be set to 0 for exact comparison.
 */
 func Equals(a, b []float64, epsilon float64) bool {
-	if len(a) != len(b) {
-		return false
+	equal, _ := EqualsWith(a, b, epsilon, nil)
+	return equal
+}
+
+/*
+EqualsWith returns a boolean value whether two []float64 data inputs are equal within a given epsilon
+with some options. NaN values, when propagated, are not equal to any value.
+Epsilon value can be set to 0 for exact comparison.
+It returns an error if any data input contains NaN values when they are not allowed
+*/
+func EqualsWith(a, b []float64, epsilon float64, opts *Options) (bool, error) {
+	a, nanA, err := opts.clean(a)
+	if err != nil {
+		return false, err
+	}
+
+	b, nanB, err := opts.clean(b)
+	if err != nil {
+		return false, err
+	}
+
+	if len(a) != len(b) || nanA || nanB {
+		return false, nil
 	}
 
 	for i := 0; i < len(a); i++ {
 		if math.Abs(a[i]-b[i]) > epsilon {
-			return false
+			return false, nil
 		}
 	}
 
-	return true
+	return true, nil
 }
 
 /*
@@ -280,8 +519,29 @@ Intersection provides a []float64 with the common elements between two data inpu
 Epsilon value can be set to 0 for exact comparison.
 */
 func Intersection(a, b []float64, epsilon float64) []float64 {
+	intersection, _ := IntersectionWith(a, b, epsilon, nil)
+	return intersection
+}
+
+/*
+IntersectionWith provides a []float64 with the common elements between two data inputs within a given epsilon
+with some options. NaN values, when propagated, are never common as they are not equal to any value.
+Epsilon value can be set to 0 for exact comparison.
+It returns an error if any data input contains NaN values when they are not allowed
+*/
+func IntersectionWith(a, b []float64, epsilon float64, opts *Options) ([]float64, error) {
+	a, err := opts.filter(a)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err = opts.filter(b)
+	if err != nil {
+		return nil, err
+	}
+
 	if len(a) == 0 || len(b) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	intersection := make([]float64, 0)
@@ -294,7 +554,7 @@ func Intersection(a, b []float64, epsilon float64) []float64 {
 		}
 	}
 
-	return intersection
+	return intersection, nil
 }
 
 /*
@@ -303,17 +563,47 @@ Repeated elements are only included once.
 Epsilon value can be set to 0 for exact comparison.
 */
 func Union(a, b []float64, epsilon float64) []float64 {
+	union, _ := UnionWith(a, b, epsilon, nil)
+	return union
+}
+
+/*
+UnionWith provides a []float64 with all the elements between two data inputs within a given epsilon
+with some options. Repeated elements are only included once, as well as NaN values when propagated.
+Epsilon value can be set to 0 for exact comparison.
+It returns an error if any data input contains NaN values when they are not allowed
+*/
+func UnionWith(a, b []float64, epsilon float64, opts *Options) ([]float64, error) {
+	a, err := opts.filter(a)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err = opts.filter(b)
+	if err != nil {
+		return nil, err
+	}
+
 	if len(a) == 0 {
-		return b
+		return b, nil
 	}
 	if len(b) == 0 {
-		return a
+		return a, nil
 	}
 
 	seen := make(map[float64]bool)
+	seenNaN := false
 	union := make([]float64, 0)
 
 	addIfNotSeen := func(value float64) {
+		if math.IsNaN(value) {
+			if !seenNaN {
+				seenNaN = true
+				union = append(union, value)
+			}
+			return
+		}
+
 		for existing := range seen {
 			if math.Abs(existing-value) <= epsilon {
 				return
@@ -330,7 +620,7 @@ func Union(a, b []float64, epsilon float64) []float64 {
 		addIfNotSeen(v)
 	}
 
-	return union
+	return union, nil
 }
 
 /*
@@ -338,16 +628,30 @@ IQR computes the interquartile range of a []float64 data input.
 It returns an error if the data is empty
 */
 func IQR(data []float64) (float64, error) {
+	return IQRWith(data, nil)
+}
+
+/*
+IQRWith computes the interquartile range of a []float64 data input with some options,
+estimating the quartiles with their quantile method.
+It returns an error if the data is empty or contains NaN values when they are not allowed
+*/
+func IQRWith(data []float64, opts *Options) (float64, error) {
+	data, err := opts.filter(data)
+	if err != nil {
+		return 0, err
+	}
+
 	if len(data) == 0 {
 		return 0, ErrEmptyData
 	}
 
-	q3, err := Percentile(data, 75)
+	q3, err := PercentileWith(data, 75, opts)
 	if err != nil {
 		return 0, err
 	}
 
-	q1, err := Percentile(data, 25)
+	q1, err := PercentileWith(data, 25, opts)
 	if err != nil {
 		return 0, err
 	}
@@ -360,6 +664,21 @@ Percentile computes the percentile value of a []float64 data input given a perce
 It returns an error if the data is empty or the percentage is out of range (0 - 100%)
 */
 func Percentile(data []float64, p float64) (float64, error) {
+	return PercentileWith(data, p, nil)
+}
+
+/*
+PercentileWith computes the percentile value of a []float64 data input given a percentage(%) value
+with some options, estimating it with their quantile method.
+It returns an error if the data is empty, the percentage is out of range (0 - 100%)
+or data contains NaN values when they are not allowed
+*/
+func PercentileWith(data []float64, p float64, opts *Options) (float64, error) {
+	data, nan, err := opts.clean(data)
+	if err != nil {
+		return 0, err
+	}
+
 	if len(data) == 0 {
 		return 0, ErrEmptyData
 	}
@@ -368,27 +687,58 @@ func Percentile(data []float64, p float64) (float64, error) {
 		return 0, ErrInvalidPercentile
 	}
 
-	sorted := Sort(data)
-	n := len(sorted)
-	pos := p * float64(n+1) / 100
+	if nan {
+		return math.NaN(), nil
+	}
+
+	return opts.quantileMethod().selectQuantile(append([]float64(nil), data...), p/100), nil
+}
 
-	if pos-1 <= 0 {
-		return sorted[0], nil
+/*
+Percentiles computes several percentile values of a []float64 data input given their percentage(%) values,
+sorting data only once.
+It returns an error if the data is empty or any percentage is out of range (0 - 100%)
+*/
+func Percentiles(data []float64, ps []float64) ([]float64, error) {
+	return PercentilesWith(data, ps, nil)
+}
+
+/*
+PercentilesWith computes several percentile values of a []float64 data input given their percentage(%) values
+with some options, sorting data only once and estimating them with their quantile method.
+It returns an error if the data is empty, any percentage is out of range (0 - 100%)
+or data contains NaN values when they are not allowed
+*/
+func PercentilesWith(data []float64, ps []float64, opts *Options) ([]float64, error) {
+	data, nan, err := opts.clean(data)
+	if err != nil {
+		return nil, err
 	}
 
-	if pos >= float64(n) {
-		return sorted[n-1], nil
+	if len(data) == 0 {
+		return nil, ErrEmptyData
 	}
 
-	if pos == float64(int(pos)) {
-		return sorted[int(pos)-1], nil
+	for _, p := range ps {
+		if p < 0 || p > 100 {
+			return nil, ErrInvalidPercentile
+		}
 	}
 
-	lower := sorted[int(pos)-1]
-	upper := sorted[int(pos)]
-	weight := pos - float64(int(pos))
+	res := make([]float64, len(ps))
+	if nan {
+		for i := range res {
+			res[i] = math.NaN()
+		}
+		return res, nil
+	}
 
-	return lower + (upper-lower)*weight, nil
+	sorted := Sort(data)
+	method := opts.quantileMethod()
+	for i, p := range ps {
+		res[i] = method.quantile(sorted, p/100)
+	}
+	return res, nil
 }
 
 /*
@@ -398,30 +748,36 @@ Quantile computes the quantile value of a []float64 data input given a quantile
 It returns an error if the data is emptty or the quantile index is out of range (0 - n)
 */
 func Quantile(data []float64, qs float64, n uint) (float64, error) {
+	return QuantileWith(data, qs, n, nil)
+}
+
+/*
+QuantileWith computes the quantile value of a []float64 data input given a quantile index with some options,
+estimating it with their quantile method.
+- qs: desired quantile index
+- n: total amount of quantiles
+It returns an error if the data is empty, the quantile index is out of range (0 - n)
+or data contains NaN values when they are not allowed
+*/
+func QuantileWith(data []float64, qs float64, n uint, opts *Options) (float64, error) {
+	data, nan, err := opts.clean(data)
+	if err != nil {
+		return 0, err
+	}
+
 	if len(data) == 0 {
 		return 0, ErrEmptyData
 	}
 
-	if qs < 0 || qs > float64(n) {
+	if n == 0 || qs < 0 || qs > float64(n) {
 		return 0, ErrInvalideQuantile
 	}
 
-	q := qs / float64(n)
-	sorted := Sort(data)
-	pos := q * float64(len(sorted)+1)
-	if pos-1 <= 0 {
-		return sorted[0], nil
-	}
-
-	if pos >= float64(n) {
-		return sorted[n-1], nil
+	if nan {
+		return math.NaN(), nil
 	}
 
-	lower := sorted[int(pos)-1]
-	upper := sorted[int(pos)]
-	weight := pos - float64(int(pos))
-
-	return lower + (upper-lower)*weight, nil
+	return opts.quantileMethod().selectQuantile(append([]float64(nil), data...), qs/float64(n)), nil
 }
 
 /*
@@ -429,27 +785,42 @@ Skewness computes the skewness value of a []float64 data input.
 It returns an error if the data is empty or the standard deviation is null
 */
 func Skewness(data []float64) (float64, error) {
-	stdDev, err := StandardDeviation(data)
+	return SkewnessWith(data, nil)
+}
+
+/*
+SkewnessWith computes the skewness value of a []float64 data input with some options.
+It returns an error if the data is empty, the standard deviation is null,
+data contains NaN values when they are not allowed or has less than 3 values for the sample estimator
+*/
+func SkewnessWith(data []float64, opts *Options) (float64, error) {
+	data, err := opts.filter(data)
 	if err != nil {
 		return 0, err
 	}
 
-	if stdDev == 0 {
-		return 0, ErrNullStdDeviation
+	n := len(data)
+	sample := opts.estimator() == SampleEstimator
+	if sample && n > 0 && n < 3 {
+		return 0, ErrNotEnoughData
 	}
 
-	n := len(data)
-	mean, err := Mean(data)
+	stdDev, err := StandardDeviationWith(data, opts.population())
 	if err != nil {
 		return 0, err
 	}
 
-	sum := 0.0
-	for v := 0; v < n; v++ {
-		sum += math.Pow((data[v] - mean), 3)
+	if stdDev == 0 {
+		return 0, ErrNullStdDeviation
 	}
 
-	return sum / (float64(n) * math.Pow(stdDev, 3)), nil
+	sum := centralSum(data, 3, opts.summation())
+	g1 := sum / (float64(n) * math.Pow(stdDev, 3))
+	if sample {
+		fn := float64(n)
+		return g1 * math.Sqrt(fn*(fn-1)) / (fn - 2), nil
+	}
+	return g1, nil
 }
 
 /*
@@ -457,27 +828,42 @@ Kurtosis computes the kurtosis value of a []float64 data input.
 It returns an error if the data is empty or the standard deviation is null
 */
 func Kurtosis(data []float64) (float64, error) {
-	stdDev, err := StandardDeviation(data)
+	return KurtosisWith(data, nil)
+}
+
+/*
+KurtosisWith computes the excess kurtosis value of a []float64 data input with some options.
+It returns an error if the data is empty, the standard deviation is null,
+data contains NaN values when they are not allowed or has less than 4 values for the sample estimator
+*/
+func KurtosisWith(data []float64, opts *Options) (float64, error) {
+	data, err := opts.filter(data)
 	if err != nil {
 		return 0, err
 	}
 
-	if stdDev == 0 {
-		return 0, ErrNullStdDeviation
+	n := len(data)
+	sample := opts.estimator() == SampleEstimator
+	if sample && n > 0 && n < 4 {
+		return 0, ErrNotEnoughData
 	}
 
-	n := len(data)
-	mean, err := Mean(data)
+	stdDev, err := StandardDeviationWith(data, opts.population())
 	if err != nil {
 		return 0, err
 	}
 
-	sum := 0.0
-	for v := 0; v < n; v++ {
-		sum += math.Pow((data[v] - mean), 4)
+	if stdDev == 0 {
+		return 0, ErrNullStdDeviation
 	}
 
-	return (sum/(float64(n)*math.Pow(stdDev, 4)) - 3), nil
+	sum := centralSum(data, 4, opts.summation())
+	g2 := sum/(float64(n)*math.Pow(stdDev, 4)) - 3
+	if sample {
+		fn := float64(n)
+		return ((fn+1)*g2 + 6) * (fn - 1) / ((fn - 2) * (fn - 3)), nil
+	}
+	return g2, nil
 }
 
 /*
@@ -485,12 +871,32 @@ Frequency computes the frequency of each value of a []float64 data input within
 It returns an error if the data is empty
 */
 func Frequency(data []float64, epsilon float64) (map[float64]int, error) {
+	return FrequencyWith(data, epsilon, nil)
+}
+
+/*
+FrequencyWith computes the frequency of each value of a []float64 data input within a given epsilon
+with some options. NaN values, when propagated, are counted together under a single NaN key.
+It returns an error if the data is empty or contains NaN values when they are not allowed
+*/
+func FrequencyWith(data []float64, epsilon float64, opts *Options) (map[float64]int, error) {
+	data, err := opts.filter(data)
+	if err != nil {
+		return nil, err
+	}
+
 	if len(data) == 0 {
 		return nil, ErrEmptyData
 	}
 
 	freq := make(map[float64]int, 0)
+	nanCount := 0
 	addIfNotSeen := func(value float64) {
+		if math.IsNaN(value) {
+			nanCount++
+			return
+		}
+
 		for existing := range freq {
 			if math.Abs(existing-value) <= epsilon {
 				freq[existing]++
@@ -504,6 +910,9 @@ func Frequency(data []float64, epsilon float64) (map[float64]int, error) {
 		addIfNotSeen(v)
 	}
 
+	if nanCount > 0 {
+		freq[math.NaN()] = nanCount
+	}
 	return freq, nil
 }
 
@@ -515,6 +924,23 @@ It can be set to a specific log base:
 It returns an error if the data is empty or the log base is invalid
 */
 func Entropy(data []float64, logBase float64) (float64, error) {
+	return EntropyWith(data, logBase, nil)
+}
+
+/*
+EntropyWith computes the entropy value of a []float64 data input with some options.
+It can be set to a specific log base:
+  - logBase = 0: natural entropy
+
+It returns an error if the data is empty, the log base is invalid
+or data contains NaN values when they are not allowed
+*/
+func EntropyWith(data []float64, logBase float64, opts *Options) (float64, error) {
+	data, nan, err := opts.clean(data)
+	if err != nil {
+		return 0, err
+	}
+
 	n := len(data)
 	if n == 0 {
 		return 0, ErrEmptyData
@@ -524,6 +950,10 @@ func Entropy(data []float64, logBase float64) (float64, error) {
 		return 0, ErrInvalidLogBase
 	}
 
+	if nan {
+		return math.NaN(), nil
+	}
+
 	freq, err := Frequency(data, 1e-8)
 	if err != nil {
 		return 0, err
@@ -0,0 +1,66 @@
+package stats
+
+import "math"
+
+/*
+Summation defines how the values are added up by the aggregating functions:
+  - NaiveSummation: adds the values in order, the fastest one but its error grows with the number of values
+  - NeumaierSummation: Kahan-Babuška (Neumaier) compensated summation, which keeps track of the rounding
+    error of every addition and is accurate even with large offsets or cancellations
+  - PairwiseSummation: recursively adds up both halves of the values, whose error grows logarithmically
+*/
+type Summation int
+
+const (
+	NaiveSummation Summation = iota
+	NeumaierSummation
+	PairwiseSummation
+)
+
+// Number of values below which pairwise summation adds the values naively
+const pairwiseBlock = 128
+
+// Sum returns the sum of n terms, given by their index, with the summation method
+func (s Summation) Sum(n int, term func(i int) float64) float64 {
+	switch s {
+	case NeumaierSummation:
+		sum, c := 0.0, 0.0
+		for i := 0; i < n; i++ {
+			x := term(i)
+			t := sum + x
+			if math.Abs(sum) >= math.Abs(x) {
+				c += (sum - t) + x
+			} else {
+				c += (x - t) + sum
+			}
+			sum = t
+		}
+		if math.IsInf(sum, 0) || math.IsNaN(sum) {
+			// Compensations are meaningless once infinite values are involved
+			return sum
+		}
+		return sum + c
+	case PairwiseSummation:
+		return pairwiseSum(0, n, term)
+	default:
+		sum := 0.0
+		for i := 0; i < n; i++ {
+			sum += term(i)
+		}
+		return sum
+	}
+}
+
+// Returns the sum of the terms with index in [from, to) adding up both halves recursively
+func pairwiseSum(from, to int, term func(i int) float64) float64 {
+	if to-from <= pairwiseBlock {
+		sum := 0.0
+		for i := from; i < to; i++ {
+			sum += term(i)
+		}
+		return sum
+	}
+
+	mid := from + (to-from)/2
+	return pairwiseSum(from, mid, term) + pairwiseSum(mid, to, term)
+}
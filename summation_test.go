@@ -0,0 +1,108 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSummation(t *testing.T) {
+	tenths := make([]float64, 1000000)
+	for i := range tenths {
+		tenths[i] = 0.1
+	}
+
+	tests := []struct {
+		name      string
+		data      []float64
+		expected  float64
+		tolerance map[Summation]float64
+	}{
+		{
+			name:     "Cancellation",
+			data:     []float64{1, 1e100, 1, -1e100},
+			expected: 2,
+			// Naive and pairwise summation lose both ones
+			tolerance: map[Summation]float64{NaiveSummation: 2, NeumaierSummation: 0, PairwiseSummation: 2},
+		},
+		{
+			name:      "Accumulated rounding errors",
+			data:      tenths,
+			expected:  100000,
+			tolerance: map[Summation]float64{NaiveSummation: 2e-6, NeumaierSummation: 0, PairwiseSummation: 1e-9},
+		},
+		{
+			name:      "Empty data",
+			data:      []float64{},
+			expected:  0,
+			tolerance: map[Summation]float64{NaiveSummation: 0, NeumaierSummation: 0, PairwiseSummation: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for s, tol := range tt.tolerance {
+				sum, _ := SumWith(tt.data, &Options{Summation: s})
+				if math.Abs(sum-tt.expected) > tol {
+					t.Errorf("expected sum with summation %v: %v, got:%v", s, tt.expected, sum)
+				}
+			}
+		})
+	}
+
+	if naive := Sum(tenths); naive == 100000 {
+		t.Errorf("expected naive summation to accumulate rounding errors, got:%v", naive)
+	}
+}
+
+func TestSummationMoments(t *testing.T) {
+	// A large offset and many values: the mean is 1e8 + 0.5 and the variance 0.25
+	data := make([]float64, 200001)
+	for i := range data {
+		data[i] = 1e8 + float64(i%2)
+	}
+	data[len(data)-1] = 1e8 + 0.5
+
+	n := float64(len(data))
+	expectedMean := 1e8 + 0.5
+	expectedVariance := 0.25 * (n - 1) / n
+
+	for _, s := range []Summation{NeumaierSummation, PairwiseSummation} {
+		opts := &Options{Summation: s}
+		mean, err := MeanWith(data, opts)
+		if err != nil {
+			t.Fatalf("unexpected error received: %v", err)
+		}
+		if mean != expectedMean {
+			t.Errorf("expected mean with summation %v: %v, got:%v", s, expectedMean, mean)
+		}
+
+		variance, _ := VarianceWith(data, opts)
+		if math.Abs(variance-expectedVariance) > 1e-12 {
+			t.Errorf("expected variance with summation %v: %v, got:%v", s, expectedVariance, variance)
+		}
+
+		skewness, _ := SkewnessWith(data, opts)
+		if math.Abs(skewness) > 1e-9 {
+			t.Errorf("expected skewness with summation %v: %v, got:%v", s, 0, skewness)
+		}
+
+		sum, _ := SumWith(data, opts)
+		parallel, _ := ParallelSum(data, &ParallelOptions{ChunkSize: 1000, Options: *opts})
+		if parallel != sum {
+			t.Errorf("expected parallel sum with summation %v: %v, got:%v", s, sum, parallel)
+		}
+	}
+
+	// Default options keep the naive summation
+	for name, f := range map[string][2]func([]float64) (float64, error){
+		"mean":     {Mean, func(d []float64) (float64, error) { return MeanWith(d, nil) }},
+		"variance": {Variance, func(d []float64) (float64, error) { return VarianceWith(d, &Options{}) }},
+		"kurtosis": {Kurtosis, func(d []float64) (float64, error) { return KurtosisWith(d, nil) }},
+	} {
+		expected, _ := f[0](data)
+		got, _ := f[1](data)
+		if got != expected {
+			t.Errorf("expected %v with default options: %v, got:%v", name, expected, got)
+		}
+	}
+}